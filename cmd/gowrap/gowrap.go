@@ -16,6 +16,7 @@ func init() {
 
 	gowrap.RegisterCommand("gen", gowrap.NewGenerateCommand(ldr))
 	gowrap.RegisterCommand("template", gowrap.NewTemplateCommand(ldr))
+	gowrap.RegisterCommand("regenerate", gowrap.NewRegenerateCommand(ldr))
 }
 
 func main() {
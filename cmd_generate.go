@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"unicode"
@@ -26,12 +27,21 @@ type GenerateCommand struct {
 	template      string
 	outputFile    string
 	sourcePkg     string
+	stdinPkgPath  string
 	noGenerate    bool
 	vars          vars
 	localPrefix   string
+	include       patterns
+	exclude       patterns
+	buildFlags    string
+	assert        bool
+	decoratorName string
+	narrowIface   string
+	primary       string
 
 	loader   templateLoader
 	filepath fs
+	stdin    io.Reader
 }
 
 // NewGenerateCommand creates GenerateCommand
@@ -44,18 +54,27 @@ func NewGenerateCommand(l remoteTemplateLoader) *GenerateCommand {
 			Dir:       filepath.Dir,
 			WriteFile: os.WriteFile,
 		},
+		stdin: os.Stdin,
 	}
 
 	//this flagset loads flags values to the command fields
 	fs := &flag.FlagSet{}
 	fs.BoolVar(&gc.noGenerate, "g", false, "don't put //go:generate instruction to the generated code")
-	fs.StringVar(&gc.interfaceName, "i", "", `the source interface name, i.e. "Reader"`)
-	fs.StringVar(&gc.sourcePkg, "p", "", "the source package import path, i.e. \"io\", \"github.com/hexdigest/gowrap\" or\na relative import path like \"./generator\"")
-	fs.StringVar(&gc.outputFile, "o", "", "the output file name")
+	fs.StringVar(&gc.interfaceName, "i", "", `the source interface name, i.e. "Reader", or a comma-separated list of names,\ni.e. "Reader,Writer", to generate decorators for several interfaces into the same file`)
+	fs.StringVar(&gc.sourcePkg, "p", "", "the source package import path, i.e. \"io\", \"github.com/hexdigest/gowrap\" or\na relative import path like \"./generator\", use \"-\" to read the source file from stdin")
+	fs.StringVar(&gc.stdinPkgPath, "stdin-importpath", "", "the import path of the source file read from stdin, the file is considered to be\na part of the destination package by default. Interfaces embedded from other packages\ncan't be resolved when the source is read from stdin")
+	fs.StringVar(&gc.outputFile, "o", "", "the output file name, use \"-\" to write the generated code to stdout")
 	fs.StringVar(&gc.template, "t", "", "the template to use, it can be an HTTPS URL, local file or a\nreference to a template in gowrap repository,\n"+
 		"run `gowrap template list` for details")
 	fs.Var(&gc.vars, "v", "a key-value pair to parametrize the template,\narguments without an equal sign are treated as a bool values,\ni.e. -v foo=bar -v disableChecks")
 	fs.StringVar(&gc.localPrefix, "l", "", "put imports beginning with this string after 3rd-party packages; comma-separated list")
+	fs.Var(&gc.include, "include", "a glob pattern of the names of the methods to decorate, all methods are decorated by default,\ni.e. -include Get* -include Set*")
+	fs.Var(&gc.exclude, "exclude", "a glob pattern of the names of the methods that shouldn't be decorated,\nexclusion takes precedence over inclusion, i.e. -exclude *Internal")
+	fs.StringVar(&gc.decoratorName, "name", "", "the name of the generated decorator type, templates name it themselves by default,\ni.e. -name ReaderWithTracing")
+	fs.StringVar(&gc.narrowIface, "narrow-interface", "", "the name of the interface declared along with the decorator, it has only the methods\nselected with -include and -exclude, i.e. -narrow-interface Getter")
+	fs.StringVar(&gc.primary, "primary-embedded", "", "the name of the interface embedded into the source one which methods are forwarded\nto the base implementation without decoration, i.e. -primary-embedded io.Closer")
+	fs.BoolVar(&gc.assert, "assert", false, "put the compile-time assertion that the decorator implements the source interface to the generated code")
+	fs.StringVar(&gc.buildFlags, "buildflags", "", `the space-separated flags passed to the build system when the source and destination packages are loaded,\ni.e. -buildflags "-tags=linux"`)
 
 	gc.BaseCommand = BaseCommand{
 		Short: "generate decorators",
@@ -87,11 +106,15 @@ func (gc *GenerateCommand) Run(args []string, stdout io.Writer) error {
 	}
 
 	buf := bytes.NewBuffer([]byte{})
-
 	if err := gen.Generate(buf); err != nil {
 		return err
 	}
 
+	if gc.outputFile == generator.StdoutFile {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+
 	if err := os.MkdirAll(filepath.Dir(gc.outputFile), os.ModePerm); err != nil {
 		return err
 	}
@@ -103,8 +126,14 @@ var (
 	errNoOutputFile    = CommandLineError("output file is not specified")
 	errNoInterfaceName = CommandLineError("interface name is not specified")
 	errNoTemplate      = CommandLineError("no template specified")
+	errNameAmbiguous   = CommandLineError("decorator name can't be set for several interfaces")
+	errNoStdinSource   = CommandLineError("stdin import path is set but the source is not read from stdin")
+	errNarrowAmbiguous = CommandLineError("narrow interface name can't be set for several interfaces")
 )
 
+// stdinSource is the source package that makes the command read the source file from stdin
+const stdinSource = "-"
+
 func (gc *GenerateCommand) checkFlags() error {
 	if gc.outputFile == "" {
 		return errNoOutputFile
@@ -118,6 +147,18 @@ func (gc *GenerateCommand) checkFlags() error {
 		return errNoTemplate
 	}
 
+	if gc.decoratorName != "" && strings.Contains(gc.interfaceName, ",") {
+		return errNameAmbiguous
+	}
+
+	if gc.narrowIface != "" && strings.Contains(gc.interfaceName, ",") {
+		return errNarrowAmbiguous
+	}
+
+	if gc.stdinPkgPath != "" && gc.sourcePkg != stdinSource {
+		return errNoStdinSource
+	}
+
 	return nil
 }
 
@@ -128,12 +169,17 @@ func (gc *GenerateCommand) getOptions() (*generator.Options, error) {
 		Funcs:          helperFuncs,
 		HeaderTemplate: headerTemplate,
 		HeaderVars: map[string]interface{}{
-			"DisableGoGenerate": gc.noGenerate,
-			"OutputFileName":    filepath.Base(gc.outputFile),
-			"VarsArgs":          varsToArgs(gc.vars),
+			//go generate can't replay the command reading the source from stdin
+			"DisableGoGenerate": gc.noGenerate || gc.sourcePkg == stdinSource,
 		},
-		Vars:        gc.vars.toMap(),
-		LocalPrefix: gc.localPrefix,
+		Vars:            gc.vars.toMap(),
+		LocalPrefix:     gc.localPrefix,
+		IncludeMethods:  gc.include,
+		ExcludeMethods:  gc.exclude,
+		AssertInterface: gc.assert,
+		DecoratorName:   gc.decoratorName,
+		NarrowInterface: gc.narrowIface,
+		PrimaryEmbedded: gc.primary,
 	}
 
 	outputFileDir, err := gc.filepath.Abs(gc.filepath.Dir(gc.outputFile))
@@ -145,15 +191,130 @@ func (gc *GenerateCommand) getOptions() (*generator.Options, error) {
 		gc.sourcePkg = "./"
 	}
 
-	sourcePackage, err := pkg.Load(gc.sourcePkg)
+	options.BuildFlags, err = splitArgs(gc.buildFlags)
+	if err != nil {
+		return nil, CommandLineError("invalid build flags: " + err.Error())
+	}
+
+	if gc.sourcePkg == stdinSource {
+		options.Source, err = io.ReadAll(gc.stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read source from stdin")
+		}
+
+		options.SourcePackage = gc.stdinPkgPath
+	} else {
+		sourcePackage, err := pkg.Load(gc.sourcePkg, options.BuildFlags...)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load source package")
+		}
+
+		options.SourcePackage = sourcePackage.PkgPath
+	}
+
+	body, templateURL, err := gc.loadTemplate(outputFileDir)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load source package")
+		return nil, err
 	}
 
-	options.SourcePackage = sourcePackage.PkgPath
-	options.BodyTemplate, options.HeaderVars["Template"], err = gc.loadTemplate(outputFileDir)
+	options.BodyTemplate = body
+	options.HeaderVars["Template"] = templateURL
+	options.HeaderVars["GenerateArgs"] = gc.generateArgs(options.SourcePackage, templateURL)
 
-	return &options, err
+	return &options, nil
+}
+
+// generateArgs returns the arguments of the gen command that are recorded in the header of the generated file,
+// they are relative to the output file directory so the file can be regenerated with go generate or gowrap regenerate
+func (gc *GenerateCommand) generateArgs(sourcePackage, template string) string {
+	if gc.sourcePkg == stdinSource {
+		sourcePackage = stdinSource
+	}
+
+	args := "-p " + quoteArg(sourcePackage) +
+		" -i " + quoteArg(gc.interfaceName) +
+		" -t " + quoteArg(template) +
+		" -o " + quoteArg(filepath.Base(gc.outputFile))
+
+	if gc.stdinPkgPath != "" {
+		args += " -stdin-importpath " + quoteArg(gc.stdinPkgPath)
+	}
+
+	if gc.buildFlags != "" {
+		args += " -buildflags " + quoteArg(gc.buildFlags)
+	}
+
+	if gc.assert {
+		args += " -assert"
+	}
+
+	if gc.decoratorName != "" {
+		args += " -name " + quoteArg(gc.decoratorName)
+	}
+
+	if gc.narrowIface != "" {
+		args += " -narrow-interface " + quoteArg(gc.narrowIface)
+	}
+
+	if gc.primary != "" {
+		args += " -primary-embedded " + quoteArg(gc.primary)
+	}
+
+	return args + varsToArgs(gc.vars) + gc.include.toArgs("include") + gc.exclude.toArgs("exclude") + " -l " + strconv.Quote(gc.localPrefix)
+}
+
+// quoteArg quotes the command line argument if it can't be passed as is, i.e. if it contains spaces
+func quoteArg(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\"\\") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+var errUnterminatedQuote = errors.New("unterminated quoted string")
+
+// splitArgs splits the arguments recorded in the header of the generated file,
+// double-quoted arguments are unquoted the same way go generate does it
+func splitArgs(s string) ([]string, error) {
+	var args []string
+
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return args, nil
+		}
+
+		if s[0] != '"' {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+
+			args = append(args, s[:end])
+			s = s[end:]
+			continue
+		}
+
+		end := 1
+		for ; end < len(s) && s[end] != '"'; end++ {
+			if s[end] == '\\' {
+				end++
+			}
+		}
+
+		if end >= len(s) {
+			return nil, errors.Wrap(errUnterminatedQuote, s)
+		}
+
+		arg, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return nil, errors.Wrap(err, s[:end+1])
+		}
+
+		args = append(args, arg)
+		s = s[end+1:]
+	}
 }
 
 type readerFunc func(path string) ([]byte, error)
@@ -169,7 +330,7 @@ func (gc *GenerateCommand) loadTemplate(outputFileDir string) (contents, url str
 		return "", "", errors.Wrap(err, "failed to load template")
 	}
 
-	if !strings.HasPrefix(url, "https://") {
+	if !isURL(url) {
 		templatePath, err := gc.filepath.Abs(url)
 		if err != nil {
 			return "", "", err
@@ -184,6 +345,12 @@ func (gc *GenerateCommand) loadTemplate(outputFileDir string) (contents, url str
 	return string(body), url, nil
 }
 
+// isURL returns true if the template is loaded by an HTTP or HTTPS URL,
+// such templates are recorded in the generated files as is
+func isURL(template string) bool {
+	return strings.HasPrefix(template, "https://") || strings.HasPrefix(template, "http://")
+}
+
 // Load implements templateLoader
 func (l loader) Load(template string) (tmpl []byte, url string, err error) {
 	tmpl, err = l.fileReader(template)
@@ -253,15 +420,38 @@ func varsToArgs(v vars) string {
 	for _, vf := range v {
 		switch typedValue := vf.value.(type) {
 		case string:
-			ss = append(ss, vf.name+"="+typedValue)
+			ss = append(ss, quoteArg(vf.name+"="+typedValue))
 		case bool:
-			ss = append(ss, vf.name)
+			ss = append(ss, quoteArg(vf.name))
 		}
 	}
 
 	return " -v " + strings.Join(ss, " -v ")
 }
 
+// patterns is a helper type that implements flag.Value to read multiple glob patterns from the command line
+type patterns []string
+
+// String implements flag.Value
+func (p patterns) String() string {
+	return strings.Join(p, ",")
+}
+
+// Set implements flag.Value
+func (p *patterns) Set(s string) error {
+	*p = append(*p, s)
+	return nil
+}
+
+func (p patterns) toArgs(flag string) string {
+	var args string
+	for _, pattern := range p {
+		args += " -" + flag + " " + quoteArg(pattern)
+	}
+
+	return args
+}
+
 var helperFuncs template.FuncMap
 
 func init() {
@@ -298,14 +488,21 @@ func toSnakeCase(str string) string {
 	return strings.ToLower(result)
 }
 
-const headerTemplate = `// Code generated by gowrap. DO NOT EDIT.
+// generatedMarker is the first line of the files generated by gowrap
+const generatedMarker = "// Code generated by gowrap. DO NOT EDIT."
+
+// headerTemplate records the gen command arguments either as a go:generate instruction
+// or as a plain comment when the instruction is disabled, so the file can be regenerated anyway
+const headerTemplate = generatedMarker + `
 // template: {{.Options.HeaderVars.Template}}
 // gowrap: http://github.com/hexdigest/gowrap
 
 package {{.Package.Name}}
 
 {{if (not .Options.HeaderVars.DisableGoGenerate)}}
-//{{"go:generate"}} gowrap gen -p {{.SourcePackage.PkgPath}} -i {{.Options.InterfaceName}} -t {{.Options.HeaderVars.Template}} -o {{.Options.HeaderVars.OutputFileName}}{{.Options.HeaderVars.VarsArgs}} -l "{{.Options.LocalPrefix}}"
+//{{"go:generate"}} gowrap gen {{.Options.HeaderVars.GenerateArgs}}
+{{else}}
+// gowrap gen -g {{.Options.HeaderVars.GenerateArgs}}
 {{end}}
 
 `
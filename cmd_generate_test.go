@@ -1,9 +1,11 @@
 package gowrap
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/hexdigest/gowrap/generator"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewGenerateCommand(t *testing.T) {
@@ -205,16 +208,28 @@ func TestGenerateCommand_Run(t *testing.T) {
 		},
 		{
 			name: "failed to create generator",
-			args: []string{"-o", "pkg/out.file", "-i", "interface", "-t", "template/template"},
+			args: []string{"-o", "pkg/out.go", "-i", "interface", "-t", "template/template"},
 			init: func(t minimock.Tester) *GenerateCommand {
 				loader := newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("{{."), "local/file", nil)
 				return NewGenerateCommand(loader)
 			},
 			wantErr: true,
 		},
+		{
+			name: "output file without the go extension",
+			args: []string{"-o", "decorators/command", "-i", "Command", "-t", "template/template"},
+			init: func(t minimock.Tester) *GenerateCommand {
+				loader := newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("//comment"), "local/file", nil)
+				return NewGenerateCommand(loader)
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, "decorators/command: output file must have the .go extension", err.Error())
+			},
+		},
 		{
 			name: "code generation error",
-			args: []string{"-o", "out.file", "-i", "Command", "-t", "template/template"},
+			args: []string{"-o", "out.go", "-i", "Command", "-t", "template/template"},
 			init: func(t minimock.Tester) *GenerateCommand {
 				loader := newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("body"), "local/file", nil)
 				return NewGenerateCommand(loader)
@@ -223,7 +238,7 @@ func TestGenerateCommand_Run(t *testing.T) {
 		},
 		{
 			name: "success",
-			args: []string{"-o", "out.file", "-i", "Command", "-t", "template/template"},
+			args: []string{"-o", "out.go", "-i", "Command", "-t", "template/template"},
 			init: func(t minimock.Tester) *GenerateCommand {
 				cmd := NewGenerateCommand(nil)
 				cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("//comment"), "local/file", nil)
@@ -234,7 +249,7 @@ func TestGenerateCommand_Run(t *testing.T) {
 		},
 		{
 			name: "success with local prefixes",
-			args: []string{"-o", "out.file", "-i", "Command", "-t", "template/template", "-l", "foobar.com/pkg"},
+			args: []string{"-o", "out.go", "-i", "Command", "-t", "template/template", "-l", "foobar.com/pkg"},
 			init: func(mt minimock.Tester) *GenerateCommand {
 				cmd := NewGenerateCommand(nil)
 				cmd.loader = newRemoteTemplateLoaderMock(mt).LoadMock.Return([]byte(`import (
@@ -294,6 +309,541 @@ func TestGenerateCommand_Run(t *testing.T) {
 	}
 }
 
+func TestGenerateCommand_Run_stdout(t *testing.T) {
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("type decorator struct{ {{.Interface.Type}} }"), "local/file", nil)
+	cmd.filepath.WriteFile = func(string, []byte, os.FileMode) error {
+		t.Fatal("unexpected write to the file")
+		return nil
+	}
+
+	stdout := bytes.NewBuffer([]byte{})
+
+	err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", "template/template"}, stdout)
+	assert.NoError(t, err)
+
+	assert.Contains(t, stdout.String(), "package gowrap")
+	assert.Contains(t, stdout.String(), "type decorator struct{ Command }")
+}
+
+func TestGenerateCommand_Run_templateURL(t *testing.T) {
+	for _, url := range []string{"https://host/template", "http://host/template"} {
+		t.Run(url, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("type decorator struct{ {{.Interface.Type}} }"), url, nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+
+			err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", url}, stdout)
+			require.NoError(t, err)
+
+			assert.Contains(t, stdout.String(), "// template: "+url+"\n")
+			assert.Contains(t, stdout.String(), "-t "+url+" ")
+		})
+	}
+}
+
+func TestGenerateCommand_Run_severalInterfaces(t *testing.T) {
+	body := []byte(`{{ $decorator := (printf "%sDecorator" .Interface.Name) }}
+		type {{$decorator}} struct{ {{.Interface.Type}} }
+		{{range $m := .Interface.Methods}}
+		func (d {{$decorator}}) {{$m.Declaration}} {
+			{{$m.Pass "d."}}
+		}
+		{{end}}`)
+
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+
+	stdout := bytes.NewBuffer([]byte{})
+
+	err := cmd.Run([]string{"-o", "-", "-i", "Command,templateLoader", "-t", "template/template"}, stdout)
+	require.NoError(t, err)
+
+	code := stdout.String()
+	assert.Equal(t, 1, strings.Count(code, "package gowrap"))
+	assert.Equal(t, 1, strings.Count(code, "//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command,templateLoader"))
+	assert.Equal(t, 1, strings.Count(code, `"io"`))
+	assert.Contains(t, code, "type CommandDecorator struct{ Command }")
+	assert.Contains(t, code, "type templateLoaderDecorator struct{ templateLoader }")
+	assert.Contains(t, code, "func (d templateLoaderDecorator) Load(path string) (tmpl []byte, url string, err error) {")
+}
+
+func TestGenerateCommand_Run_filterMethods(t *testing.T) {
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("{{range $m := .Interface.Methods}}\n// {{$m.Name}}{{end}}"), "local/file", nil)
+
+	stdout := bytes.NewBuffer([]byte{})
+
+	err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", "template/template", "-include", "*e*", "-include", "Run", "-exclude", "Usage*"}, stdout)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout.String(), "-include *e* -include Run -exclude Usage* -l")
+	assert.Contains(t, stdout.String(), "// FlagSet")
+	assert.Contains(t, stdout.String(), "// HelpMessage")
+	assert.Contains(t, stdout.String(), "// Run")
+	assert.Contains(t, stdout.String(), "// ShortDescription")
+	assert.NotContains(t, stdout.String(), "// UsageLine")
+}
+
+func TestGenerateCommand_Run_buildFlags(t *testing.T) {
+	body := []byte("{{range $m := .Interface.Methods}}\n// {{$m.Name}}{{end}}")
+
+	t.Run("default build", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+
+		stdout := bytes.NewBuffer([]byte{})
+		require.NoError(t, cmd.Run([]string{"-o", "-", "-p", "./generator/testdata/tagged", "-i", "Service", "-t", "template/template"}, stdout))
+
+		assert.Contains(t, stdout.String(), "// Default")
+		assert.NotContains(t, stdout.String(), "-buildflags")
+	})
+
+	t.Run("custom build tag", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+
+		stdout := bytes.NewBuffer([]byte{})
+		require.NoError(t, cmd.Run([]string{"-o", "-", "-p", "./generator/testdata/tagged", "-i", "Service", "-t", "template/template", "-buildflags", "-tags=special"}, stdout))
+
+		assert.Contains(t, stdout.String(), "// Special")
+		assert.NotContains(t, stdout.String(), "// Default")
+		assert.Contains(t, stdout.String(), "-buildflags -tags=special")
+	})
+
+	t.Run("invalid build flags", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+
+		err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", "template/template", "-buildflags", `"-tags=special`}, bytes.NewBuffer([]byte{}))
+		assert.IsType(t, CommandLineError(""), err)
+	})
+}
+
+func TestGenerateCommand_Run_stdin(t *testing.T) {
+	body := []byte(`{{.Import}}
+		type decorator struct{ {{.Interface.Type}} }
+		{{range $m := .Interface.Methods}}
+		func (d decorator) {{$m.Declaration}} {
+			{{$m.Pass "d."}}
+		}
+		{{end}}`)
+	source := "package source\n\nimport \"io\"\n\ntype Copier interface {\n\tCopy(dst io.Writer, src io.Reader) (int64, error)\n}\n"
+
+	t.Run("source import path", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+		cmd.stdin = strings.NewReader(source)
+
+		stdout := bytes.NewBuffer([]byte{})
+		require.NoError(t, cmd.Run([]string{"-o", "-", "-p", "-", "-stdin-importpath", "example.com/source", "-i", "Copier", "-t", "template/template"}, stdout))
+
+		assert.Contains(t, stdout.String(), "// gowrap gen -g -p - -i Copier -t local/file -o - -stdin-importpath example.com/source -l")
+		assert.NotContains(t, stdout.String(), "go:generate")
+		assert.Contains(t, stdout.String(), `"example.com/source"`)
+		assert.Contains(t, stdout.String(), `"io"`)
+		assert.Contains(t, stdout.String(), "type decorator struct{ source.Copier }")
+		assert.Contains(t, stdout.String(), "func (d decorator) Copy(dst io.Writer, src io.Reader) (i1 int64, err error) {")
+	})
+
+	t.Run("destination package", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+		cmd.stdin = strings.NewReader(source)
+
+		stdout := bytes.NewBuffer([]byte{})
+		require.NoError(t, cmd.Run([]string{"-o", "-", "-p", "-", "-i", "Copier", "-t", "template/template"}, stdout))
+
+		assert.Contains(t, stdout.String(), "// gowrap gen -g -p - -i Copier -t local/file -o - -l")
+		assert.Contains(t, stdout.String(), "type decorator struct{ Copier }")
+	})
+
+	t.Run("embedded interface of another package", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+		cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(body, "local/file", nil)
+		cmd.stdin = strings.NewReader("package source\n\nimport \"io\"\n\ntype Closer interface {\n\tio.Closer\n}\n")
+
+		err := cmd.Run([]string{"-o", "-", "-p", "-", "-i", "Closer", "-t", "template/template"}, bytes.NewBuffer([]byte{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to find package io")
+	})
+
+	t.Run("import path without stdin source", func(t *testing.T) {
+		cmd := NewGenerateCommand(nil)
+
+		err := cmd.Run([]string{"-o", "-", "-stdin-importpath", "example.com/source", "-i", "Command", "-t", "template/template"}, bytes.NewBuffer([]byte{}))
+		assert.Equal(t, errNoStdinSource, err)
+	})
+}
+
+func TestGenerateCommand_Run_assert(t *testing.T) {
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return([]byte("type decorator struct{ {{.Interface.Type}} }\n{{.Assert.Implements \"decorator\"}}"), "local/file", nil)
+
+	stdout := bytes.NewBuffer([]byte{})
+
+	err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", "template/template", "-assert"}, stdout)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout.String(), "-o - -assert -l")
+	assert.Contains(t, stdout.String(), "var _ Command = (*decorator)(nil)")
+}
+
+func TestGenerateCommand_Run_decoratorName(t *testing.T) {
+	logTemplate, err := os.ReadFile("templates/log")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "default name",
+			want: "type CommandWithLog struct {",
+		},
+		{
+			name: "custom name",
+			args: []string{"-name", "LoggingCommand"},
+			want: "type LoggingCommand struct {",
+		},
+		{
+			name:    "invalid name",
+			args:    []string{"-name", "Logging-Command"},
+			wantErr: "Logging-Command: decorator name is not a valid identifier",
+		},
+		{
+			name:    "several interfaces",
+			args:    []string{"-name", "LoggingCommand", "-i", "Command,templateLoader"},
+			wantErr: errNameAmbiguous.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(logTemplate, "templates/log", nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+
+			err := cmd.Run(append([]string{"-o", "-", "-i", "Command", "-t", "log"}, tt.args...), stdout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.want)
+			assert.Contains(t, stdout.String(), strings.Join(append([]string{"-o", "-"}, tt.args...), " ")+" -l")
+		})
+	}
+}
+
+func TestGenerateCommand_Run_narrowInterface(t *testing.T) {
+	logTemplate, err := os.ReadFile("templates/log")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "narrow interface",
+			args: []string{"-narrow-interface", "Runner", "-include", "Run"},
+			want: "type Runner interface {",
+		},
+		{
+			name:    "invalid name",
+			args:    []string{"-narrow-interface", "Run-ner"},
+			wantErr: "Run-ner: narrow interface name is not a valid identifier",
+		},
+		{
+			name:    "several interfaces",
+			args:    []string{"-narrow-interface", "Runner", "-i", "Command,templateLoader"},
+			wantErr: errNarrowAmbiguous.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(logTemplate, "templates/log", nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+
+			err := cmd.Run(append([]string{"-o", "-", "-i", "Command", "-t", "log"}, tt.args...), stdout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.want)
+			assert.Contains(t, stdout.String(), "-o - -narrow-interface Runner -include Run -l")
+		})
+	}
+}
+
+func TestGenerateCommand_Run_primaryEmbedded(t *testing.T) {
+	logTemplate, err := os.ReadFile("templates/log")
+	require.NoError(t, err)
+
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(logTemplate, "templates/log", nil)
+
+	stdout := bytes.NewBuffer([]byte{})
+
+	err = cmd.Run([]string{"-o", "-", "-p", "io", "-i", "ReadCloser", "-t", "log", "-primary-embedded", "Closer"}, stdout)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout.String(), "func (_d ReadCloserWithLog) Read(")
+	assert.Contains(t, stdout.String(), "func (_d ReadCloserWithLog) Close() (err error) {\n\treturn _d._base.Close()\n}")
+	assert.Contains(t, stdout.String(), "-o - -primary-embedded Closer -l")
+}
+
+func TestGenerateCommand_Run_primaryEmbeddedSeveralImplementations(t *testing.T) {
+	errgroupTemplate, err := os.ReadFile("templates/errgroup")
+	require.NoError(t, err)
+
+	cmd := NewGenerateCommand(nil)
+	cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(errgroupTemplate, "templates/errgroup", nil)
+
+	err = cmd.Run([]string{"-o", "-", "-p", "io", "-i", "ReadCloser", "-t", "errgroup", "-primary-embedded", "Closer"}, io.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "errgroup calls several implementations, it can't forward the methods of Closer to a single one")
+}
+
+func TestGenerateCommand_Run_skipDirective(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		iface          string
+		vars           []string
+		wantContains   []string
+		wantNotContain string
+		wantErr        string
+	}{
+		{
+			name:         "batch",
+			template:     "batch",
+			vars:         []string{"-v", "BatchMethods=Delete"},
+			wantContains: []string{"type SkippedWithBatchingDeleteCall struct", "func (_d *SkippedWithBatching) Save(key string) (err error) {\n\treturn _d.Skipped.Save(key)\n}"},
+		},
+		{
+			name:     "batch skipped method",
+			template: "batch",
+			vars:     []string{"-v", "BatchMethods=Save"},
+			wantErr:  "BatchMethods: method Save is marked with gowrap:skip or promoted from the primary embedded interface",
+		},
+		{
+			name:           "health",
+			template:       "health",
+			wantContains:   []string{"_d.Skipped.Ready()", "func (_d SkippedWithHealth) Ping() (err error) {\n\treturn _d.Skipped.Ping()\n}"},
+			wantNotContain: "_d.Skipped.Ping(); err != nil",
+		},
+		{name: "func", template: "func", iface: "SkippedFunc", wantErr: "func: method Do can't be skipped, there is no base implementation to forward it to"},
+		{name: "errgroup", template: "errgroup", wantErr: "errgroup calls several implementations, it can't forward the Ping method marked with gowrap:skip to a single one"},
+		{name: "fallback", template: "fallback", wantErr: "fallback calls several implementations, it can't forward the Ping method marked with gowrap:skip to a single one"},
+		{name: "robinpool", template: "robinpool", wantErr: "robinpool calls several implementations, it can't forward the Ping method marked with gowrap:skip to a single one"},
+		{name: "syncpool", template: "syncpool", wantErr: "syncpool calls several implementations, it can't forward the Ping method marked with gowrap:skip to a single one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := os.ReadFile("templates/" + tt.template)
+			require.NoError(t, err)
+
+			iface := tt.iface
+			if iface == "" {
+				iface = "Skipped"
+			}
+
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(tmpl, "templates/"+tt.template, nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+			args := append([]string{"-o", "-", "-p", "./generator/testdata/source", "-i", iface, "-t", tt.template}, tt.vars...)
+
+			err = cmd.Run(args, stdout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, stdout.String(), want)
+			}
+			if tt.wantNotContain != "" {
+				assert.NotContains(t, stdout.String(), tt.wantNotContain)
+			}
+		})
+	}
+}
+
+func TestGenerateCommand_Run_recoverMethods(t *testing.T) {
+	recoverTemplate, err := os.ReadFile("templates/recover")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		methods string
+		wantErr string
+	}{
+		{
+			name:    "error returning method",
+			methods: "Run",
+		},
+		{
+			name:    "unknown method",
+			methods: "Run,Unknown",
+			wantErr: "RecoverMethods: Command has no method Unknown",
+		},
+		{
+			name:    "method without error",
+			methods: "ShortDescription",
+			wantErr: "RecoverMethods: method ShortDescription doesn't return an error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(recoverTemplate, "templates/recover", nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+
+			err := cmd.Run([]string{"-o", "-", "-i", "Command", "-t", "recover", "-v", "RecoverMethods=" + tt.methods}, stdout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), "func (_d CommandWithRecover) Run(args []string, stdout io.Writer) (err error) {")
+			assert.NotContains(t, stdout.String(), "ShortDescription")
+		})
+	}
+}
+
+func TestGenerateCommand_Run_timeouts(t *testing.T) {
+	timeoutTemplate, err := os.ReadFile("templates/timeout")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		vars    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no timeouts",
+			want: "return FetcherWithTimeoutConfig{}",
+		},
+		{
+			name: "default timeout",
+			vars: []string{"-v", "DefaultTimeout=time.Second"},
+			want: "FetchTimeout: time.Second,",
+		},
+		{
+			name: "per-method timeout takes precedence over the default one",
+			vars: []string{"-v", "Timeouts=Fetch=100*time.Millisecond", "-v", "DefaultTimeout=time.Second"},
+			want: "FetchTimeout: 100 * time.Millisecond,",
+		},
+		{
+			name:    "unknown method",
+			vars:    []string{"-v", "Timeouts=Fetch=time.Second,Unknown=time.Second"},
+			wantErr: "Timeouts: Fetcher has no method Unknown",
+		},
+		{
+			name:    "method without context",
+			vars:    []string{"-v", "Timeouts=Len=time.Second"},
+			wantErr: "Timeouts: method Len doesn't accept context",
+		},
+		{
+			name:    "timeout is not specified",
+			vars:    []string{"-v", "Timeouts=Fetch"},
+			wantErr: `Timeouts: "Fetch" must be in the method=duration format`,
+		},
+		{
+			name:    "default timeout is not specified",
+			vars:    []string{"-v", "DefaultTimeout"},
+			wantErr: "DefaultTimeout: the duration is not specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(timeoutTemplate, "templates/timeout", nil)
+
+			stdout := bytes.NewBuffer([]byte{})
+
+			args := append([]string{"-o", "-", "-p", "./generator/testdata/source", "-i", "Fetcher", "-t", "timeout"}, tt.vars...)
+			err := cmd.Run(args, stdout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.want)
+			assert.NotContains(t, stdout.String(), "LenTimeout")
+		})
+	}
+}
+
+func TestGenerateCommand_Run_recoverPanicFormat(t *testing.T) {
+	recoverTemplate, err := os.ReadFile("templates/recover")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name: "default format",
+			want: `err = fmt.Errorf("Command.Run: panic: %v", _r)`,
+		},
+		{
+			name:   "custom format",
+			format: `{method} of {interface} panicked with "{panic}" (100%)`,
+			want:   `err = fmt.Errorf("Run of Command panicked with \"%v\" (100%%)", _r)`,
+		},
+		{
+			name:   "format without panic value",
+			format: "{method} panicked",
+			want:   `err = fmt.Errorf("Run panicked")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCommand(nil)
+			cmd.loader = newRemoteTemplateLoaderMock(t).LoadMock.Return(recoverTemplate, "templates/recover", nil)
+
+			args := []string{"-o", "-", "-i", "Command", "-t", "recover", "-v", "RecoverMethods=Run"}
+			if tt.format != "" {
+				args = append(args, "-v", "PanicFormat="+tt.format)
+			}
+
+			stdout := bytes.NewBuffer([]byte{})
+			require.NoError(t, cmd.Run(args, stdout))
+			assert.Contains(t, stdout.String(), tt.want)
+		})
+	}
+}
+
 func Test_varsToArgs(t *testing.T) {
 	tests := []struct {
 		name  string
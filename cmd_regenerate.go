@@ -0,0 +1,245 @@
+package gowrap
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hexdigest/gowrap/generator"
+	remoteloader "github.com/hexdigest/gowrap/loader"
+	"github.com/pkg/errors"
+)
+
+// RegenerateCommand implements Command interface
+type RegenerateCommand struct {
+	BaseCommand
+
+	newGenerateCommand func() *GenerateCommand
+}
+
+// NewRegenerateCommand creates RegenerateCommand
+func NewRegenerateCommand(l remoteTemplateLoader) *RegenerateCommand {
+	return &RegenerateCommand{
+		BaseCommand: BaseCommand{
+			Short: "regenerate decorators",
+			Usage: "[path ...]",
+			Help: `
+Regenerate finds the files generated by gowrap and regenerates them using
+the arguments recorded in their headers. Paths ending with "/..." are walked
+recursively, the current directory tree is used if no paths are given, i.e.
+
+  gowrap regenerate ./...
+
+Files whose source interface or template doesn't exist anymore are skipped
+with a warning, any other failure is reported after the rest of the files
+are regenerated.
+`,
+		},
+		newGenerateCommand: func() *GenerateCommand {
+			return NewGenerateCommand(l)
+		},
+	}
+}
+
+// Run implements Command interface
+func (rc *RegenerateCommand) Run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	var failures []string
+	for _, path := range args {
+		files, err := generatedFiles(path)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			err := rc.regenerate(file)
+			switch {
+			case err == nil:
+				fmt.Fprintf(stdout, "regenerated %s\n", file)
+			case isStale(err):
+				fmt.Fprintf(stdout, "skipping %s: %v\n", file, err)
+			default:
+				failures = append(failures, fmt.Sprintf("%s: %v", file, err))
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Wrap(errRegenerate, strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+var errRegenerate = errors.New("failed to regenerate files")
+
+// isStale checks whether the file can't be regenerated because its source interface or template
+// doesn't exist anymore or because it was generated to the stdout or from the stdin
+func isStale(err error) bool {
+	for _, target := range []error{generator.ErrTargetNotFound, remoteloader.ErrTemplateNotFound, errStdoutOutput, errStdinSource} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rc *RegenerateCommand) regenerate(file string) error {
+	args, err := readGenerateArgs(file)
+	if err != nil {
+		return err
+	}
+
+	args, err = rebaseArgs(args, filepath.Dir(file))
+	if err != nil {
+		return err
+	}
+
+	gc := rc.newGenerateCommand()
+	gc.FlagSet().SetOutput(io.Discard)
+
+	return gc.Run(args, io.Discard)
+}
+
+// generatedFiles returns the files generated by gowrap in the directory,
+// the subdirectories are walked too if the path ends with "/..."
+func generatedFiles(path string) ([]string, error) {
+	dir, recursive := path, false
+	if path == "..." || strings.HasSuffix(path, "/...") {
+		dir, recursive = strings.TrimSuffix(strings.TrimSuffix(path, "..."), "/"), true
+		if dir == "" {
+			dir = "."
+		}
+	}
+
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && (!recursive || ignoredDir(d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		generated, err := isGeneratedFile(path)
+		if generated {
+			files = append(files, path)
+		}
+
+		return err
+	})
+
+	return files, err
+}
+
+// ignoredDir checks whether the directory is ignored by the go tool
+func ignoredDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func isGeneratedFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	return strings.TrimSpace(line) == generatedMarker, nil
+}
+
+var generateArgsPrefixes = []string{"//go:generate gowrap gen ", "// gowrap gen "}
+
+var errNoGenerateArgs = errors.New("gen command arguments are not found in the header")
+
+// readGenerateArgs returns the arguments of the gen command recorded in the header of the generated file
+func readGenerateArgs(file string) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line := strings.TrimSpace(string(line))
+
+		for _, prefix := range generateArgsPrefixes {
+			if strings.HasPrefix(line, prefix) {
+				return splitArgs(strings.TrimPrefix(line, prefix))
+			}
+		}
+
+		//the header ends with the first declaration, single imports can precede the go:generate instruction
+		if isDeclaration(line) {
+			break
+		}
+	}
+
+	return nil, errNoGenerateArgs
+}
+
+func isDeclaration(line string) bool {
+	for _, keyword := range []string{"type", "func", "var", "const"} {
+		if strings.HasPrefix(line, keyword+" ") || strings.HasPrefix(line, keyword+"(") {
+			return true
+		}
+	}
+
+	return false
+}
+
+var errStdoutOutput = errors.New("the file was generated to the standard output")
+var errStdinSource = errors.New("the file was generated from the source read from the standard input")
+
+// rebaseArgs makes the output file and the local template paths recorded relative
+// to the directory of the generated file usable from the current working directory
+func rebaseArgs(args []string, dir string) ([]string, error) {
+	result := make([]string, len(args))
+	copy(result, args)
+
+	for i := 0; i < len(result)-1; i++ {
+		switch result[i] {
+		case "-p":
+			if result[i+1] == stdinSource {
+				return nil, errStdinSource
+			}
+		case "-o":
+			if result[i+1] == generator.StdoutFile {
+				return nil, errStdoutOutput
+			}
+			result[i+1] = filepath.Join(dir, result[i+1])
+		case "-t":
+			template := result[i+1]
+			if isURL(template) {
+				continue
+			}
+
+			//otherwise it's a reference to a template in gowrap repository
+			if _, err := os.Stat(filepath.Join(dir, template)); err == nil {
+				result[i+1] = filepath.Join(dir, template)
+			}
+		}
+	}
+
+	return result, nil
+}
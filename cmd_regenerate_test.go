@@ -0,0 +1,183 @@
+package gowrap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	remoteloader "github.com/hexdigest/gowrap/loader"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const regenerateTemplate = `type {{.Interface.Name}}Decorator struct{ {{.Interface.Type}} }`
+
+func writeGenerated(t *testing.T, dir, name, args string) string {
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(file, []byte(generatedMarker+"\n\npackage decorators\n\n"+args+"\n\ntype stale struct{}\n"), 0664))
+	return file
+}
+
+func TestRegenerateCommand_Run(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "decorators")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "testdata"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "decorator.tmpl"), []byte(regenerateTemplate), 0664))
+
+	regenerated := writeGenerated(t, dir, "command.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t decorator.tmpl -o command.go -l ""`)
+	noGenerate := writeGenerated(t, dir, "loader.go", `// gowrap gen -g -p github.com/hexdigest/gowrap -i templateLoader -t decorator.tmpl -o loader.go -l ""`)
+	deletedInterface := writeGenerated(t, dir, "deleted.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Deleted -t decorator.tmpl -o deleted.go -l ""`)
+	deletedTemplate := writeGenerated(t, dir, "template.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t deleted -o template.go -l ""`)
+	ignored := writeGenerated(t, filepath.Join(dir, "testdata"), "ignored.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t decorator.tmpl -o ignored.go -l ""`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handwritten.go"), []byte("package decorators\n"), 0664))
+
+	loader := newRemoteTemplateLoaderMock(t).LoadMock.Expect("deleted").Return(nil, "", remoteloader.ErrTemplateNotFound)
+
+	stdout := bytes.NewBuffer([]byte{})
+	require.NoError(t, NewRegenerateCommand(loader).Run([]string{root + "/..."}, stdout))
+
+	assert.Contains(t, stdout.String(), "regenerated "+regenerated+"\n")
+	assert.Contains(t, stdout.String(), "regenerated "+noGenerate+"\n")
+	assert.Contains(t, stdout.String(), "skipping "+deletedInterface+": ")
+	assert.Contains(t, stdout.String(), "Deleted: target declaration not found")
+	assert.Contains(t, stdout.String(), "skipping "+deletedTemplate+": failed to load template: remote template not found")
+	assert.NotContains(t, stdout.String(), ignored)
+	assert.NotContains(t, stdout.String(), "handwritten.go")
+
+	code, err := os.ReadFile(regenerated)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "// template: decorator.tmpl\n")
+	assert.Contains(t, string(code), `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t decorator.tmpl -o command.go -l ""`)
+	assert.Contains(t, string(code), "type CommandDecorator struct{ gowrap.Command }")
+	assert.NotContains(t, string(code), "stale")
+
+	code, err = os.ReadFile(noGenerate)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), `// gowrap gen -g -p github.com/hexdigest/gowrap -i templateLoader -t decorator.tmpl -o loader.go -l ""`)
+	assert.NotContains(t, string(code), "go:generate")
+
+	code, err = os.ReadFile(deletedInterface)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "stale")
+}
+
+func TestRegenerateCommand_Run_failure(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "decorator.tmpl"), []byte(regenerateTemplate), 0664))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.tmpl"), []byte("type {{.Interface.Name}} struct{ {{.Unknown}} }"), 0664))
+
+	broken := writeGenerated(t, dir, "broken.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t broken.tmpl -o broken.go -l ""`)
+	regenerated := writeGenerated(t, dir, "command.go", `//go:generate gowrap gen -p github.com/hexdigest/gowrap -i Command -t decorator.tmpl -o command.go -l ""`)
+
+	stdout := bytes.NewBuffer([]byte{})
+	err := NewRegenerateCommand(nil).Run([]string{dir}, stdout)
+	require.Error(t, err)
+	assert.Equal(t, errRegenerate, errors.Cause(err))
+	assert.Contains(t, err.Error(), broken+": ")
+
+	assert.Contains(t, stdout.String(), "regenerated "+regenerated+"\n")
+	assert.NotContains(t, stdout.String(), "skipping")
+}
+
+func Test_readGenerateArgs(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("no arguments in the header", func(t *testing.T) {
+		file := writeGenerated(t, dir, "noargs.go", "")
+		_, err := readGenerateArgs(file)
+		assert.Equal(t, errNoGenerateArgs, err)
+	})
+
+	t.Run("arguments after the header are ignored", func(t *testing.T) {
+		file := writeGenerated(t, dir, "body.go", "type decorator struct{}\n\n//go:generate gowrap gen -p io")
+		_, err := readGenerateArgs(file)
+		assert.Equal(t, errNoGenerateArgs, err)
+	})
+
+	t.Run("arguments after the single import", func(t *testing.T) {
+		file := writeGenerated(t, dir, "import.go", "import \"context\"\n\n//go:generate gowrap gen -p io")
+		args, err := readGenerateArgs(file)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-p", "io"}, args)
+	})
+
+	t.Run("quoted arguments", func(t *testing.T) {
+		file := writeGenerated(t, dir, "quoted.go", `//go:generate gowrap gen -p io -i Reader -t log -o reader.go -v "Prefix=read from" -l ""`)
+		args, err := readGenerateArgs(file)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-p", "io", "-i", "Reader", "-t", "log", "-o", "reader.go", "-v", "Prefix=read from", "-l", ""}, args)
+	})
+}
+
+func Test_splitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "plain arguments",
+			s:    " -p io  -i Reader\t-o reader.go",
+			want: []string{"-p", "io", "-i", "Reader", "-o", "reader.go"},
+		},
+		{
+			name: "quoted arguments",
+			s:    `-v "key=value with spaces" -v "escaped \"quote\"" -l ""`,
+			want: []string{"-v", "key=value with spaces", "-v", `escaped "quote"`, "-l", ""},
+		},
+		{
+			name:    "unterminated quote",
+			s:       `-l "prefix`,
+			wantErr: errUnterminatedQuote,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitArgs(tt.s)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, errors.Cause(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_quoteArg(t *testing.T) {
+	assert.Equal(t, "Reader", quoteArg("Reader"))
+	assert.Equal(t, `""`, quoteArg(""))
+	assert.Equal(t, `"key=value with spaces"`, quoteArg("key=value with spaces"))
+
+	args, err := splitArgs(quoteArg(`a "quoted" \ value`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`a "quoted" \ value`}, args)
+}
+
+func Test_rebaseArgs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "local"), nil, 0664))
+
+	args, err := rebaseArgs([]string{"-t", "local", "-o", "out.go"}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-t", filepath.Join(dir, "local"), "-o", filepath.Join(dir, "out.go")}, args)
+
+	args, err = rebaseArgs([]string{"-t", "retry", "-o", "out.go"}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-t", "retry", "-o", filepath.Join(dir, "out.go")}, args)
+
+	args, err = rebaseArgs([]string{"-t", "https://host/template"}, dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-t", "https://host/template"}, args)
+
+	_, err = rebaseArgs([]string{"-o", "-"}, dir)
+	assert.Equal(t, errStdoutOutput, err)
+
+	_, err = rebaseArgs([]string{"-p", "-", "-o", "out.go"}, dir)
+	assert.Equal(t, errStdinSource, err)
+}
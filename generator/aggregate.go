@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/imports"
+)
+
+var errNoGenerators = errors.New("no generators to aggregate")
+var errPackageMismatch = errors.New("decorators are generated into different packages")
+var errDuplicateDecl = errors.New("declared by several decorators")
+var errDecoratorNameAmbiguous = errors.New("decorator name can't be set for several interfaces")
+var errNarrowInterfaceAmbiguous = errors.New("narrow interface name can't be set for several interfaces")
+
+// newMultiGenerator returns a generator of the decorators of all interfaces
+// listed in the options.InterfaceName, the source package is parsed only once
+func newMultiGenerator(options Options) (*Generator, error) {
+	if options.DecoratorName != "" {
+		return nil, errDecoratorNameAmbiguous
+	}
+
+	if options.NarrowInterface != "" {
+		return nil, errNarrowInterfaceAmbiguous
+	}
+
+	if options.FileSet == nil {
+		options.FileSet = token.NewFileSet()
+	}
+
+	multi := &Generator{Options: options}
+
+	for _, name := range strings.Split(options.InterfaceName, ",") {
+		interfaceOptions := options
+		interfaceOptions.InterfaceName = strings.TrimSpace(name)
+
+		g, err := NewGenerator(interfaceOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create generator for %s", interfaceOptions.InterfaceName)
+		}
+
+		multi.interfaces = append(multi.interfaces, g)
+	}
+
+	return multi, nil
+}
+
+// GenerateAll generates decorators of all generators into a single file.
+// The header of the first generator is used for the whole file, imports of
+// all decorators are merged into a single import declaration
+func GenerateAll(w io.Writer, generators ...*Generator) error {
+	_, processedSource, err := generateAll(generators)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(processedSource)
+	return err
+}
+
+func generateAll(generators []*Generator) (source, processedSource []byte, err error) {
+	if len(generators) == 0 {
+		return nil, nil, errNoGenerators
+	}
+
+	var (
+		header      []byte
+		floating    []byte
+		packageName string
+		importSpecs []string
+		bodies      [][]byte
+	)
+
+	seenImports := map[string]bool{}
+	seenDecls := map[string]bool{}
+
+	for i, g := range generators {
+		_, decorator, err := g.generate()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to generate decorator for %s", g.Options.InterfaceName)
+		}
+
+		fs := token.NewFileSet()
+		f, err := parser.ParseFile(fs, "", decorator, parser.ParseComments)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse decorator for %s", g.Options.InterfaceName)
+		}
+
+		if i == 0 {
+			packageName = f.Name.Name
+		} else if f.Name.Name != packageName {
+			return nil, nil, errors.Wrapf(errPackageMismatch, "%s and %s", packageName, f.Name.Name)
+		}
+
+		for _, name := range declNames(f) {
+			if seenDecls[name] {
+				return nil, nil, errors.Wrap(errDuplicateDecl, name)
+			}
+			seenDecls[name] = true
+		}
+
+		for _, spec := range f.Imports {
+			importSpec := spec.Path.Value
+			if spec.Name != nil {
+				importSpec = spec.Name.Name + " " + importSpec
+			}
+
+			if !seenImports[importSpec] {
+				seenImports[importSpec] = true
+				importSpecs = append(importSpecs, importSpec)
+			}
+		}
+
+		headerEnd, importsEnd, bodyStart := len(decorator), -1, len(decorator)
+		for j, decl := range f.Decls {
+			if j == 0 {
+				//comments attached to the import declaration are kept in the header
+				headerEnd = fs.Position(decl.Pos()).Offset
+			}
+
+			if gd, ok := decl.(*ast.GenDecl); !ok || gd.Tok != token.IMPORT {
+				bodyStart = fs.Position(declPos(decl)).Offset
+				break
+			}
+
+			importsEnd = fs.Position(decl.End()).Offset
+		}
+
+		if i == 0 {
+			header = decorator[:headerEnd]
+			if importsEnd >= 0 && importsEnd < bodyStart {
+				//imports.Process puts the imports above the comments following the package clause
+				//so the header comments like go:generate instructions can be found after the imports
+				floating = bytes.TrimSpace(decorator[importsEnd:bodyStart])
+			}
+		}
+
+		bodies = append(bodies, decorator[bodyStart:])
+	}
+
+	buf := bytes.NewBuffer(header)
+	buf.WriteString("import (\n")
+	for _, spec := range importSpecs {
+		buf.WriteString("\t" + spec + "\n")
+	}
+	buf.WriteString(")\n\n")
+	if len(floating) > 0 {
+		buf.Write(floating)
+		buf.WriteString("\n\n")
+	}
+	buf.Write(bytes.Join(bodies, []byte("\n")))
+
+	imports.LocalPrefix = generators[0].localPrefix
+	processedSource, err = imports.Process(generators[0].Options.outputFile(), buf.Bytes(), nil)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to format generated code:\n%s", buf)
+	}
+
+	return buf.Bytes(), processedSource, nil
+}
+
+// declPos returns the position of the declaration including its doc comment
+func declPos(decl ast.Decl) token.Pos {
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	}
+
+	return decl.Pos()
+}
+
+// declNames returns names of the top level declarations of the file,
+// methods names are prefixed with the receiver type name
+func declNames(f *ast.File) []string {
+	var names []string
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				names = append(names, receiverName(d.Recv.List[0].Type)+"."+d.Name.Name)
+			} else {
+				names = append(names, d.Name.Name)
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						if name.Name != "_" {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func receiverName(e ast.Expr) string {
+	if se, ok := e.(*ast.StarExpr); ok {
+		return embeddedName(se.X)
+	}
+
+	return embeddedName(e)
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const aggregateBodyTemplate = `{{.Import "\"fmt\""}}
+
+// {{.Interface.Name}}Printer prints calls of {{.Interface.Type}} methods
+type {{.Interface.Name}}Printer struct {
+	{{.Interface.Embedding.Type}}
+}
+
+// New{{.Interface.Name}}Printer returns {{.Interface.Name}}Printer
+func New{{.Interface.Name}}Printer(base {{.Interface.Embedding.Type}}) {{.Interface.Name}}Printer {
+	fmt.Println("{{.Interface.Name}}")
+	return {{.Interface.Name}}Printer{base}
+}`
+
+func newAggregateGenerator(t *testing.T, interfaceName string) *Generator {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "// Code generated by test. DO NOT EDIT.\n\npackage generator\n\n//go:generate test\n\n",
+		BodyTemplate:   aggregateBodyTemplate,
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  interfaceName,
+	})
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestGenerateAll(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+
+	err := GenerateAll(buf,
+		newAggregateGenerator(t, "Iface"),
+		newAggregateGenerator(t, "OtherIface"),
+		newAggregateGenerator(t, "Arrays"),
+	)
+	require.NoError(t, err)
+
+	code := buf.String()
+	assert.True(t, strings.HasPrefix(code, "// Code generated by test. DO NOT EDIT.\n\npackage generator\n\n//go:generate test\n\nimport ("))
+	assert.Equal(t, 1, strings.Count(code, "//go:generate"))
+	assert.Equal(t, 1, strings.Count(code, "import"))
+	assert.Equal(t, 1, strings.Count(code, `"fmt"`))
+	assert.Equal(t, 1, strings.Count(code, `"github.com/hexdigest/gowrap/generator/testdata/source"`))
+
+	for _, name := range []string{"Iface", "OtherIface", "Arrays"} {
+		assert.Contains(t, code, "// "+name+"Printer prints calls of source."+name+" methods\ntype "+name+"Printer struct")
+		assert.Contains(t, code, "func New"+name+"Printer(base source."+name+") "+name+"Printer {")
+	}
+}
+
+func TestGenerateAll_duplicateDecl(t *testing.T) {
+	err := GenerateAll(bytes.NewBuffer([]byte{}),
+		newAggregateGenerator(t, "Iface"),
+		newAggregateGenerator(t, "Iface"),
+	)
+	require.Error(t, err)
+	assert.Equal(t, errDuplicateDecl, errors.Cause(err))
+	assert.Contains(t, err.Error(), "IfacePrinter")
+}
+
+func TestGenerateAll_noGenerators(t *testing.T) {
+	err := GenerateAll(bytes.NewBuffer([]byte{}))
+	assert.Equal(t, errNoGenerators, err)
+}
+
+func TestNewGenerator_severalInterfaces(t *testing.T) {
+	g := newAggregateGenerator(t, "Iface, OtherIface")
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	code := buf.String()
+	assert.Equal(t, 1, strings.Count(code, "package generator"))
+	assert.Equal(t, 1, strings.Count(code, "//go:generate"))
+	assert.Equal(t, 1, strings.Count(code, `"fmt"`))
+	assert.Contains(t, code, "type IfacePrinter struct")
+	assert.Contains(t, code, "type OtherIfacePrinter struct")
+
+	result, err := g.GenerateResult()
+	require.NoError(t, err)
+	assert.Equal(t, buf.String(), string(result.Source))
+	assert.NotEmpty(t, result.Methods)
+}
+
+func TestNewGenerator_severalInterfacesErrors(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   aggregateBodyTemplate,
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Iface,OtherIface",
+	}
+
+	t.Run("decorator name", func(t *testing.T) {
+		options := options
+		options.DecoratorName = "Printer"
+
+		_, err := NewGenerator(options)
+		assert.Equal(t, errDecoratorNameAmbiguous, err)
+	})
+
+	t.Run("narrow interface", func(t *testing.T) {
+		options := options
+		options.NarrowInterface = "Narrow"
+
+		_, err := NewGenerator(options)
+		assert.Equal(t, errNarrowInterfaceAmbiguous, err)
+	})
+
+	t.Run("unknown interface", func(t *testing.T) {
+		options := options
+		options.InterfaceName = "Iface,Unknown"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create generator for Unknown")
+	})
+
+	t.Run("colliding decorator names", func(t *testing.T) {
+		options := options
+		options.BodyTemplate = `type Printer struct{}`
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		err = g.Generate(bytes.NewBuffer([]byte{}))
+		require.Error(t, err)
+		assert.Equal(t, errDuplicateDecl, errors.Cause(err))
+		assert.Contains(t, err.Error(), "Printer")
+	})
+}
@@ -0,0 +1,331 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+)
+
+// builtinFuncs are the functions predefined by the text/template package
+var builtinFuncs = map[string]bool{
+	"and":      true,
+	"call":     true,
+	"html":     true,
+	"index":    true,
+	"slice":    true,
+	"js":       true,
+	"len":      true,
+	"not":      true,
+	"or":       true,
+	"print":    true,
+	"printf":   true,
+	"println":  true,
+	"urlquery": true,
+	"eq":       true,
+	"ge":       true,
+	"gt":       true,
+	"le":       true,
+	"lt":       true,
+	"ne":       true,
+}
+
+// generatorFuncs are the template functions provided by the generator,
+// they can be overridden by the registered functions and Options.Funcs
+var generatorFuncs = template.FuncMap{
+	"methodImports": methodImports,
+	"paramsStruct":  paramsStruct,
+	"paramNames":    paramNames,
+	"resultNames":   resultNames,
+	"isPointer":     isPointer,
+	"isSlice":       isSlice,
+	"zeroValue":     zeroValue,
+	"goQuote":       strconv.Quote,
+}
+
+// methodImports returns import paths of the packages referenced by the method's params and results
+func methodImports(m Method) []string {
+	return m.imports
+}
+
+// paramsStruct returns a literal of the anonymous struct with a field for every param of the method
+// except the leading context.Context, i.e. struct{ Arg0 int; Arg1 string }{Arg0: a, Arg1: b}.
+// Fields are named by the param position, variadic params are stored in the slice fields
+func paramsStruct(m Method) string {
+	params := m.Params
+	if m.AcceptsContext {
+		params = params[1:]
+	}
+
+	if len(params) == 0 {
+		return "struct{}{}"
+	}
+
+	fields := make([]string, 0, len(params))
+	values := make([]string, 0, len(params))
+	for i, p := range params {
+		name := "Arg" + strconv.Itoa(i)
+
+		typ := p.Type
+		if p.Variadic {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+		}
+
+		fields = append(fields, name+" "+typ)
+		values = append(values, name+": "+p.Name)
+	}
+
+	return "struct{ " + strings.Join(fields, "; ") + " }{" + strings.Join(values, ", ") + "}"
+}
+
+// paramNames returns comma separated names of the method params as they're passed
+// to the call of the decorated method, i.e. "ctx, a1, a2..."
+func paramNames(m Method) string {
+	return m.Params.Pass()
+}
+
+// resultNames returns comma separated names for the method results, the names declared
+// in the source are preserved and the unnamed results get positional names, i.e. "res0, err".
+// Positional names never collide with the params, the declared results and the receiver, so
+// the results of the decorated method can be captured with "{{resultNames $method}} := ...".
+// All the results get positional names if all of them are named in the source since
+// the := needs at least one new variable
+func resultNames(m Method) string {
+	used := map[string]bool{ReceiverName: true}
+	for _, p := range m.Params {
+		used[p.Name] = true
+	}
+
+	allDeclared := true
+	for _, r := range m.Results {
+		if r.declared {
+			used[r.Name] = true
+		} else {
+			allDeclared = false
+		}
+	}
+
+	names := make([]string, 0, len(m.Results))
+	for i, r := range m.Results {
+		name := r.Name
+		if !r.declared || allDeclared {
+			name = "res" + strconv.Itoa(i)
+			for used[name] {
+				name = "_" + name
+			}
+			used[name] = true
+		}
+
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// isPointer reports whether the type is a pointer, i.e. "*T" or "*[]byte" but not "[]*T".
+// It accepts the Param or the printed type, the check is syntactic so the named types
+// which underlying type is a pointer are not reported
+func isPointer(typ interface{}) (bool, error) {
+	expr, err := parseType(typ)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := expr.(*ast.StarExpr)
+	return ok, nil
+}
+
+// isSlice reports whether the type is a slice, i.e. "[]*T" or the variadic "...T" but not "*[]byte"
+// or the array "[2]T". It accepts the Param or the printed type, the check is syntactic so the named
+// types which underlying type is a slice are not reported
+func isSlice(typ interface{}) (bool, error) {
+	expr, err := parseType(typ)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := expr.(*ast.Ellipsis); ok {
+		return true, nil
+	}
+
+	array, ok := expr.(*ast.ArrayType)
+	return ok && array.Len == nil, nil
+}
+
+// zeroValues are the literals of the zero values of the predeclared types
+var zeroValues = map[string]string{
+	"bool": "false", "string": `""`, "error": "nil", "any": "nil",
+	"int": "0", "int8": "0", "int16": "0", "int32": "0", "int64": "0",
+	"uint": "0", "uint8": "0", "uint16": "0", "uint32": "0", "uint64": "0", "uintptr": "0",
+	"float32": "0", "float64": "0", "complex64": "0", "complex128": "0", "byte": "0", "rune": "0",
+}
+
+// zeroValue returns the literal of the zero value of the type, i.e. nil for "*T", "[]T" or "map[K]V",
+// 0 for "int", `""` for "string" and "[2]T{}" for arrays and anonymous structs. It accepts the Param or
+// the printed type. The underlying types of the named types are unknown, so their zero value is "*new(T)"
+// which is valid for the type params as well as for the named structs, numbers or interfaces
+func zeroValue(typ interface{}) (string, error) {
+	expr, err := parseType(typ)
+	if err != nil {
+		return "", err
+	}
+
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.Ellipsis, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return "nil", nil
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil", nil
+		}
+		return types.ExprString(t) + "{}", nil
+	case *ast.StructType:
+		return types.ExprString(t) + "{}", nil
+	case *ast.Ident:
+		if zero, ok := zeroValues[t.Name]; ok {
+			return zero, nil
+		}
+	}
+
+	return "*new(" + types.ExprString(expr) + ")", nil
+}
+
+var errUnsupportedType = errors.New("type must be either a string or a Param")
+
+func parseType(typ interface{}) (ast.Expr, error) {
+	var s string
+	switch t := typ.(type) {
+	case string:
+		s = t
+	case Param:
+		s = t.Type
+	case *Param:
+		s = t.Type
+	default:
+		return nil, errors.Wrapf(errUnsupportedType, "%T", typ)
+	}
+
+	if strings.HasPrefix(s, "...") {
+		return &ast.Ellipsis{}, nil
+	}
+
+	expr, err := parser.ParseExpr(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse type %q", s)
+	}
+
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr, nil
+		}
+		expr = paren.X
+	}
+}
+
+var (
+	globalFuncsMu sync.RWMutex
+	globalFuncs   = template.FuncMap{}
+)
+
+// RegisterFunc registers the template function available in templates of all generators
+// created after the registration. Functions passed in Options.Funcs take precedence over
+// the registered ones. It's safe to call RegisterFunc concurrently
+func RegisterFunc(name string, fn interface{}) {
+	globalFuncsMu.Lock()
+	defer globalFuncsMu.Unlock()
+
+	globalFuncs[name] = fn
+}
+
+// mergeFuncs returns the registered template functions merged with funcs
+func mergeFuncs(funcs template.FuncMap) template.FuncMap {
+	globalFuncsMu.RLock()
+	defer globalFuncsMu.RUnlock()
+
+	merged := make(template.FuncMap, len(generatorFuncs)+len(globalFuncs)+len(funcs))
+	for name, fn := range generatorFuncs {
+		merged[name] = fn
+	}
+
+	for name, fn := range globalFuncs {
+		merged[name] = fn
+	}
+
+	for name, fn := range funcs {
+		merged[name] = fn
+	}
+
+	return merged
+}
+
+var errUndefinedFunc = errors.New("function is not defined")
+
+// checkFuncs returns an error naming the first function used in the template
+// that is neither predefined by the text/template package nor registered in funcs.
+// Syntax errors are ignored here and reported by the template parser afterwards.
+func checkFuncs(name, text string, funcs template.FuncMap) error {
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+
+	treeSet := map[string]*parse.Tree{}
+	if _, err := tree.Parse(text, "", "", treeSet); err != nil {
+		return nil
+	}
+
+	for _, t := range treeSet {
+		if fn := undefinedFunc(t.Root, funcs); fn != "" {
+			return errors.Wrapf(errUndefinedFunc, "%s template uses %q, register it in Options.Funcs", name, fn)
+		}
+	}
+
+	return nil
+}
+
+func undefinedFunc(node parse.Node, funcs template.FuncMap) string {
+	var nodes []parse.Node
+
+	switch n := node.(type) {
+	case *parse.IdentifierNode:
+		if _, ok := funcs[n.Ident]; !ok && !builtinFuncs[n.Ident] {
+			return n.Ident
+		}
+	case *parse.ListNode:
+		if n != nil {
+			nodes = n.Nodes
+		}
+	case *parse.ActionNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.PipeNode:
+		if n != nil {
+			for _, c := range n.Cmds {
+				nodes = append(nodes, c)
+			}
+		}
+	case *parse.CommandNode:
+		nodes = n.Args
+	case *parse.ChainNode:
+		nodes = []parse.Node{n.Node}
+	case *parse.IfNode:
+		nodes = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.RangeNode:
+		nodes = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.WithNode:
+		nodes = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.TemplateNode:
+		nodes = []parse.Node{n.Pipe}
+	}
+
+	for _, n := range nodes {
+		if fn := undefinedFunc(n, funcs); fn != "" {
+			return fn
+		}
+	}
+
+	return ""
+}
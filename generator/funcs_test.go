@@ -0,0 +1,429 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkFuncs(t *testing.T) {
+	funcs := template.FuncMap{"up": func(string) string { return "" }}
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{
+			name: "builtin and registered funcs",
+			text: `{{range $m := .Methods}}{{if eq (len $m.Params) 0}}{{up $m.Name | printf "%s"}}{{end}}{{end}}`,
+		},
+		{
+			name: "syntax error is reported by the parser",
+			text: "{{.",
+		},
+		{
+			name:    "unknown func in action",
+			text:    "{{unknown .Name}}",
+			wantErr: `body template uses "unknown", register it in Options.Funcs: function is not defined`,
+		},
+		{
+			name:    "unknown func in a pipeline inside range",
+			text:    "{{range .Methods}}{{if .HasParams}}{{.Name | downFirst}}{{end}}{{end}}",
+			wantErr: `body template uses "downFirst", register it in Options.Funcs: function is not defined`,
+		},
+		{
+			name:    "unknown func in a nested template",
+			text:    `{{define "method"}}{{camel .Name}}{{end}}{{template "method" .}}`,
+			wantErr: `body template uses "camel", register it in Options.Funcs: function is not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFuncs("body", tt.text, funcs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Equal(t, errUndefinedFunc, errors.Cause(err))
+		})
+	}
+}
+
+func TestRegisterFunc(t *testing.T) {
+	wg := sync.WaitGroup{}
+	for _, name := range []string{"globalUpper", "globalLower"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			RegisterFunc(name, strings.ToUpper)
+		}(name)
+	}
+	wg.Wait()
+
+	defer func() {
+		globalFuncsMu.Lock()
+		delete(globalFuncs, "globalUpper")
+		delete(globalFuncs, "globalLower")
+		globalFuncsMu.Unlock()
+	}()
+
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   `var _ = "{{globalUpper .Interface.Name}} {{globalLower .Interface.Name}}"`,
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Iface",
+		Funcs:          template.FuncMap{"globalLower": strings.ToLower},
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `var _ = "IFACE iface"`)
+}
+
+func TestGenerator_Generate_methodImports(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{range $m := .Interface.Methods}}\n// {{$m.Name}} {{methodImports $m}}{{end}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Fetcher",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"context", "net/url"}, methodImports(g.methods["Fetch"]))
+	assert.Empty(t, methodImports(g.methods["Len"]))
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), "// Fetch [context net/url]\n// Len []")
+}
+
+func Test_paramsStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   string
+	}{
+		{
+			name:   "no params",
+			method: Method{Name: "M"},
+			want:   "struct{}{}",
+		},
+		{
+			name: "leading context is skipped",
+			method: Method{
+				Name:           "M",
+				AcceptsContext: true,
+				Params:         ParamsSlice{{Name: "ctx", Type: "context.Context"}},
+			},
+			want: "struct{}{}",
+		},
+		{
+			name: "named and unnamed params",
+			method: Method{
+				Name:           "M",
+				AcceptsContext: true,
+				Params: ParamsSlice{
+					{Name: "ctx", Type: "context.Context"},
+					{Name: "a", Type: "int"},
+					{Name: "s1", Type: "string"},
+				},
+			},
+			want: "struct{ Arg0 int; Arg1 string }{Arg0: a, Arg1: s1}",
+		},
+		{
+			name: "variadic param",
+			method: Method{
+				Name: "M",
+				Params: ParamsSlice{
+					{Name: "format", Type: "string"},
+					{Name: "args", Type: "...interface{}", Variadic: true},
+				},
+			},
+			want: "struct{ Arg0 string; Arg1 []interface{} }{Arg0: format, Arg1: args}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, paramsStruct(tt.method))
+		})
+	}
+}
+
+func TestGenerator_Generate_paramsStruct(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{.Import}}\n{{range $m := .Interface.Methods}}\nvar _ = func{{$m.Signature}} { _ = {{paramsStruct $m}}; return }{{end}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Fetcher",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), "_ = struct{ Arg0 *url.URL }{Arg0: u}")
+	assert.Contains(t, buf.String(), "_ = struct{}{}")
+}
+
+func Test_resultNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   string
+	}{
+		{
+			name:   "no results",
+			method: Method{Name: "M"},
+			want:   "",
+		},
+		{
+			name: "unnamed results",
+			method: Method{
+				Name:    "M",
+				Results: ParamsSlice{{Name: "i1", Type: "int"}, {Name: "err", Type: "error"}},
+			},
+			want: "res0, res1",
+		},
+		{
+			name: "declared names are preserved",
+			method: Method{
+				Name:    "M",
+				Results: ParamsSlice{{Name: "n", Type: "int", declared: true}, {Name: "s1", Type: "string"}},
+			},
+			want: "n, res1",
+		},
+		{
+			name: "all results are named",
+			method: Method{
+				Name:    "M",
+				Results: ParamsSlice{{Name: "n", Type: "int", declared: true}, {Name: "err", Type: "error", declared: true}},
+			},
+			want: "res0, res1",
+		},
+		{
+			name: "positional names of the named results don't collide with params",
+			method: Method{
+				Name:    "M",
+				Params:  ParamsSlice{{Name: "res0", Type: "int", declared: true}},
+				Results: ParamsSlice{{Name: ReceiverName, Type: "int", declared: true}},
+			},
+			want: "_res0",
+		},
+		{
+			name: "positional names don't collide with params and declared results",
+			method: Method{
+				Name:    "M",
+				Params:  ParamsSlice{{Name: "res0", Type: "int", declared: true}},
+				Results: ParamsSlice{{Name: "i1", Type: "int"}, {Name: "_res0", Type: "int", declared: true}},
+			},
+			want: "__res0, _res0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resultNames(tt.method))
+			assert.Equal(t, tt.want, resultNames(tt.method), "names must be the same for every call")
+		})
+	}
+}
+
+func TestGenerator_Generate_resultNames(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+			{{range $m := .Interface.Methods}}
+			func (_d decorator) {{$m.Declaration}} {
+				{{resultNames $m}} := _d.{{$.Interface.Embedding.Field}}.{{$m.Name}}({{paramNames $m}})
+				return {{resultNames $m}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Fetcher",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), "res0, res1 := _d.Fetcher.Fetch(ctx, u)\n\treturn res0, res1")
+	assert.Contains(t, buf.String(), "res0 := _d.Fetcher.Len()\n\treturn res0")
+}
+
+func Test_isPointer_isSlice(t *testing.T) {
+	tests := []struct {
+		typ         interface{}
+		wantPointer bool
+		wantSlice   bool
+	}{
+		{typ: "*url.URL", wantPointer: true},
+		{typ: "*[]byte", wantPointer: true},
+		{typ: "[]*T", wantSlice: true},
+		{typ: "[]byte", wantSlice: true},
+		{typ: "...string", wantSlice: true},
+		{typ: "[2]*T"},
+		{typ: "map[string][]int"},
+		{typ: "source.List[*T]"},
+		{typ: "func() *T"},
+		{typ: "(*T)", wantPointer: true},
+		{typ: Param{Name: "p", Type: "[]byte"}, wantSlice: true},
+		{typ: &Param{Name: "p", Type: "*bytes.Buffer"}, wantPointer: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.typ), func(t *testing.T) {
+			pointer, err := isPointer(tt.typ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPointer, pointer)
+
+			slice, err := isSlice(tt.typ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSlice, slice)
+		})
+	}
+
+	_, err := isPointer(1)
+	assert.Equal(t, errUnsupportedType, errors.Cause(err))
+
+	_, err = isSlice("[]")
+	assert.Error(t, err)
+}
+
+func Test_zeroValue(t *testing.T) {
+	tests := []struct {
+		typ  interface{}
+		want string
+	}{
+		{typ: "*url.URL", want: "nil"},
+		{typ: "[]byte", want: "nil"},
+		{typ: "...string", want: "nil"},
+		{typ: "map[string][]int", want: "nil"},
+		{typ: "<-chan int", want: "nil"},
+		{typ: "func() error", want: "nil"},
+		{typ: "interface{}", want: "nil"},
+		{typ: "any", want: "nil"},
+		{typ: "error", want: "nil"},
+		{typ: "int64", want: "0"},
+		{typ: "float64", want: "0"},
+		{typ: "rune", want: "0"},
+		{typ: "string", want: `""`},
+		{typ: "bool", want: "false"},
+		{typ: "[2]*T", want: "[2]*T{}"},
+		{typ: "struct{ A int }", want: "struct{A int}{}"},
+		{typ: "T", want: "*new(T)"},
+		{typ: "time.Duration", want: "*new(time.Duration)"},
+		{typ: "source.List[*T]", want: "*new(source.List[*T])"},
+		{typ: "(*T)", want: "nil"},
+		{typ: Param{Name: "v", Type: "V"}, want: "*new(V)"},
+		{typ: &Param{Name: "s", Type: "string"}, want: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.typ), func(t *testing.T) {
+			zero, err := zeroValue(tt.typ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, zero)
+		})
+	}
+
+	_, err := zeroValue(1)
+	assert.Equal(t, errUnsupportedType, errors.Cause(err))
+}
+
+func TestGenerator_Generate_zeroValue(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator{{.Interface.Generics.Types}} struct {
+				{{.Interface.Embedding.Type}}
+			}
+			{{range $m := .Interface.Methods}}
+			func (_d decorator{{$.Interface.Generics.Params}}) {{$m.Declaration}} {
+				if _d.{{$.Interface.Embedding.Field}} == nil {
+					return {{range $i, $r := $m.Results}}{{if $i}}, {{end}}{{zeroValue $r}}{{end}}
+				}
+				{{$m.Pass (printf "_d.%s." $.Interface.Embedding.Field)}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Keyed",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), "if _d.Keyed == nil {\n\t\treturn *new(V), nil\n\t}")
+}
+
+func TestGenerator_Generate_isPointer(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+			{{range $m := .Interface.Methods}}
+			func (_d decorator) {{$m.Declaration}} {
+				{{- range $p := $m.Params}}
+					{{- if isPointer $p}}
+						if {{$p.Name}} == nil {
+							panic("{{$p.Name}} is nil")
+						}
+					{{- end}}
+				{{- end}}
+				{{- range $r := $m.Results}}
+					{{- if isSlice $r.Type}}
+						// {{$r.Name}} is a slice
+					{{- end}}
+				{{- end}}
+				{{$m.Pass (printf "_d.%s." $.Interface.Embedding.Field)}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Fetcher",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), "if u == nil {\n\t\tpanic(\"u is nil\")\n\t}\n\t// ba1 is a slice\n\treturn _d.Fetcher.Fetch(ctx, u)")
+	assert.Equal(t, 1, strings.Count(buf.String(), "== nil"))
+}
+
+func TestGenerator_Generate_goQuote(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{range $m := .Interface.Methods}}var _ = {{goQuote $m.Name}}{{end}}
+			var _ = {{goQuote "say \"hi\"\nand bye"}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "OtherIface",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), `var _ = "Name"`)
+	assert.Contains(t, buf.String(), `var _ = "say \"hi\"\nand bye"`)
+}
@@ -2,15 +2,23 @@ package generator
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"go/ast"
+	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
 	"text/template"
+	"unicode"
 
 	"github.com/pkg/errors"
 	"golang.org/x/tools/go/packages"
@@ -30,9 +38,14 @@ type Generator struct {
 	dstPackage     *packages.Package
 	methods        methodsList
 	interfaceType  string
+	targetType     string
 	genericTypes   string
 	genericParams  string
 	localPrefix    string
+
+	//interfaces are generators of every interface of the comma-separated Options.InterfaceName,
+	//their decorators are generated into a single file
+	interfaces []*Generator
 }
 
 // TemplateInputs information passed to template for generation
@@ -42,74 +55,206 @@ type TemplateInputs struct {
 	// Vars additional vars to pass to the template, see Options.Vars
 	Vars    map[string]interface{}
 	Imports []string
+	// Constructor helps to generate a constructor that validates the base implementation, see Options.NilBaseCheck
+	Constructor TemplateInputConstructor
+	// Assert helps to generate a compile-time assertion that the decorator implements the interface, see Options.AssertInterface
+	Assert TemplateInputAssert
 }
 
 // Import generates an import statement using a list of imports from the source file
-// along with the ones from the template itself
+// along with the ones from the template itself, the statement is formatted the way gofmt
+// formats it and it's empty if there is nothing to import
 func (t TemplateInputs) Import(imports ...string) string {
 	allImports := make(map[string]struct{}, len(imports)+len(t.Imports))
 
-	for _, i := range t.Imports {
-		allImports[strings.TrimSpace(i)] = struct{}{}
-	}
-
-	for _, i := range imports {
-		if len(i) == 0 {
-			continue
-		}
-
-		i = strings.TrimSpace(i)
-
-		if i[len(i)-1] != '"' {
-			i += `"`
-		}
-
-		if i[0] != '"' {
-			i = `"` + i
+	for _, list := range [][]string{t.Imports, imports} {
+		for _, i := range list {
+			if i = normalizeImport(i); i != "" {
+				allImports[i] = struct{}{}
+			}
 		}
+	}
 
-		allImports[i] = struct{}{}
+	if len(allImports) == 0 {
+		return ""
 	}
 
 	out := make([]string, 0, len(allImports))
 
 	for i := range allImports {
-		out = append(out, i)
+		out = append(out, "\t"+i+"\n")
 	}
 
 	sort.Strings(out)
 
-	return "import (\n" + strings.Join(out, "\n") + ")\n"
+	return "import (\n" + strings.Join(out, "") + ")\n"
+}
+
+// normalizeImport returns the import spec with the quoted path separated from the optional
+// name by a single space, i.e. `alias "path/to/pkg"`, so the same imports are deduplicated
+func normalizeImport(spec string) string {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	path := len(fields) - 1
+	fields[path] = `"` + strings.Trim(fields[path], `"`) + `"`
+
+	return strings.Join(fields, " ")
 }
 
 // TemplateInputInterface subset of interface information used for template generation
 type TemplateInputInterface struct {
 	Name string
+	// DecoratorName is a name of the generated type set with Options.DecoratorName,
+	// it's empty if templates should name the type themselves
+	DecoratorName string
 	// Type of the interface, with package name qualifier (e.g. sort.Interface)
 	Type string
 	// Generics of the interface when using generics
 	Generics TemplateInputGenerics
 	// Methods name keyed map of method information
 	Methods map[string]Method
+	// Embedding describes how the interface is embedded into the decorator
+	Embedding TemplateInputEmbedding
+	// Target is a type of the interface implemented by the adapter (e.g. io.Reader),
+	// it's empty unless Options.TargetInterface is set
+	Target string
+	// PrimaryEmbedded is a name of the embedded interface which methods are forwarded to the base implementation
+	// without decoration (e.g. io.Closer), it's empty unless Options.PrimaryEmbedded is set
+	PrimaryEmbedded string
+}
+
+// TemplateInputEmbedding describes the anonymous field used to embed the decorated interface into the decorator struct,
+// methods that are not decorated are promoted from this field
+type TemplateInputEmbedding struct {
+	// Field is a name of the embedded field that can be used to access the base implementation (e.g. _d.Interface)
+	Field string
+	// Type of the embedded field including generics params (e.g. sort.Interface or store.Store[K, V])
+	Type string
+}
+
+// TemplateInputConstructor helps templates to generate a decorator constructor
+// that checks the base implementation is not nil
+type TemplateInputConstructor struct {
+	NilBaseCheck NilBaseCheck
+}
+
+// Results returns the results of the constructor of the decorator type,
+// an error is added to the results when the nil base check returns an error
+func (c TemplateInputConstructor) Results(decorator string) string {
+	if c.NilBaseCheck == NilBaseError {
+		return "(" + decorator + ", error)"
+	}
+
+	return decorator
+}
+
+// CheckBase returns a statement that panics or returns an error if the base is nil,
+// it returns an empty string if the check is disabled
+func (c TemplateInputConstructor) CheckBase(base, decorator string) string {
+	message := strconv.Quote(strings.TrimPrefix(decorator, "*") + ": base implementation is nil")
+
+	switch c.NilBaseCheck {
+	case NilBasePanic:
+		return "if " + base + " == nil {\npanic(" + message + ")\n}"
+	case NilBaseError:
+		zero := "nil"
+		if !strings.HasPrefix(decorator, "*") {
+			zero = decorator + "{}"
+		}
+		return "if " + base + " == nil {\nreturn " + zero + ", errors.New(" + message + ")\n}"
+	}
+
+	return ""
+}
+
+// Return returns the return statement of the constructor
+func (c TemplateInputConstructor) Return(decorator string) string {
+	if c.NilBaseCheck == NilBaseError {
+		return "return " + decorator + ", nil"
+	}
+
+	return "return " + decorator
+}
+
+// TemplateInputAssert helps templates to generate a compile-time assertion that the decorator
+// implements the interface, so the package stops compiling when the interface changes and
+// the decorator isn't regenerated
+type TemplateInputAssert struct {
+	Enabled bool
+	// Type of the source interface (e.g. sort.Interface)
+	Type string
+	// Generics of the interface
+	Generics TemplateInputGenerics
+}
+
+// Implements returns a declaration asserting that the pointer to the decorator implements the source interface,
+// it returns an empty string if the assertion is disabled. Generic types can't be used uninstantiated
+// outside of the generic declarations so the assertion for the generic decorator is put into a blank function
+func (a TemplateInputAssert) Implements(decorator string) string {
+	if !a.Enabled {
+		return ""
+	}
+
+	assertion := "var _ " + a.Type + a.Generics.Params + " = (*" + decorator + a.Generics.Params + ")(nil)"
+	if a.Generics.Types == "" {
+		return assertion
+	}
+
+	return "func _" + a.Generics.Types + "() {\n" + assertion + "\n}"
 }
 
+// NilBaseCheck defines how the decorator constructor handles the nil base implementation
+type NilBaseCheck string
+
+const (
+	// NilBaseIgnore disables the check, it's the default
+	NilBaseIgnore NilBaseCheck = ""
+	// NilBasePanic makes the constructor panic if the base implementation is nil
+	NilBasePanic NilBaseCheck = "panic"
+	// NilBaseError makes the constructor return an error along with the decorator
+	NilBaseError NilBaseCheck = "error"
+)
+
 // Options of the NewGenerator constructor
 type Options struct {
-	//InterfaceName is a name of interface type
+	//InterfaceName is a name of interface type or a comma-separated list of names,
+	//decorators of all listed interfaces are generated into a single file
 	InterfaceName string
 
 	//Imports from the file with interface definition
 	Imports []string
 
+	//AdditionalImports are always available to the templates along with the Imports, i.e. `"strings"` or `alias "path/to/pkg"`.
+	//Unused imports are removed from the generated code
+	AdditionalImports []string
+
 	//SourcePackage is an import path or a relative path of the package that contains the source interface
 	SourcePackage string
 
+	//Source is the Go source of the file declaring the source interface, when it's set the file is parsed instead
+	//of loading the SourcePackage and the SourcePackage is used as the import path of the file. The file is considered
+	//to be a part of the destination package if the SourcePackage is empty. Interfaces embedded from other packages
+	//can't be resolved in this case since the imports of the file are not loaded
+	Source []byte
+
 	//SourcePackageAlias is an import selector defauls is source package name
 	SourcePackageAlias string
 
-	//OutputFile name which is used to detect destination package name and also to fix imports in the resulting source
+	//OutputFile name which is used to detect destination package name and also to fix imports in the resulting source.
+	//Use StdoutFile when the generated code is written to the standard output
 	OutputFile string
 
+	//OutputPackageName overrides the destination package name, when it's empty the name of the package
+	//found in the OutputFile directory is used or the name of the directory itself if there's no package
+	OutputPackageName string
+
+	//DestinationPackagePath is an import path of the destination package, it overrides the package
+	//found in the OutputFile directory, i.e. when the generated file is moved to another package afterwards
+	DestinationPackagePath string
+
 	//HeaderTemplate is used to generate package clause and comment over the generated source
 	HeaderTemplate string
 
@@ -128,6 +273,56 @@ type Options struct {
 	//LocalPrefix is a comma-separated string of import path prefixes, which, if set, instructs Process to sort the import
 	//paths with the given prefixes into another group after 3rd-party packages.
 	LocalPrefix string
+
+	//FileSet is used to parse the source packages, it can be shared between several generators
+	//to reuse it across generations. If it's nil a new file set is created
+	FileSet *token.FileSet
+
+	//TargetInterface is a name of the interface declared in the source package which method set is a subset
+	//of the source interface methods. If it's set only the methods of the target interface are passed to the
+	//templates so the generated type can adapt the source interface to the target one
+	TargetInterface string
+
+	//TypeCheckOutput enables type checking of the generated code within the destination package.
+	//It helps to catch template bugs early but it's expensive since the destination package has to be loaded
+	TypeCheckOutput bool
+
+	//NilBaseCheck defines whether the decorator constructor panics or returns an error if the base
+	//implementation is nil. Templates support it with the TemplateInputs.Constructor helpers
+	NilBaseCheck NilBaseCheck
+
+	//Deprecated is a deprecation notice added to the doc comment of the generated type
+	//so the linters warn about the usages of the decorator
+	Deprecated string
+
+	//IncludeMethods are glob patterns (see path.Match) of the names of the methods passed to the templates,
+	//i.e. "Get*". All methods are passed to the templates if it's empty
+	IncludeMethods []string
+
+	//ExcludeMethods are glob patterns of the names of the methods that are not passed to the templates,
+	//i.e. "*Internal". Exclusion takes precedence over inclusion
+	ExcludeMethods []string
+
+	//BuildFlags are passed to the build system when the packages are loaded, i.e. "-tags=integration".
+	//Declarations from the files excluded by the build constraints are ignored
+	BuildFlags []string
+
+	//DecoratorName is a name of the generated type passed to the templates, it allows to generate
+	//several decorators of the same interface into one package. Templates name the type themselves if it's empty
+	DecoratorName string
+
+	//AssertInterface enables the compile-time assertions that the generated decorators implement
+	//the interface. Templates support it with the TemplateInputs.Assert helper
+	AssertInterface bool
+
+	//NarrowInterface is a name of the interface declared along with the decorator, it has only the methods
+	//passed to the templates so the method set narrowed with the IncludeMethods and ExcludeMethods is a named type
+	NarrowInterface string
+
+	//PrimaryEmbedded is a name of the interface embedded into the source one as it's written in the source code,
+	//i.e. "io.Closer". Its methods are passed to the templates with the Primary flag set so they're forwarded
+	//to the base implementation without decoration and only the methods of the other embedded interfaces are decorated
+	PrimaryEmbedded string
 }
 
 type methodsList map[string]Method
@@ -138,17 +333,23 @@ type processInput struct {
 	astPackage     *ast.Package
 	targetName     string
 	genericParams  genericParams
+	dstPackagePath string
+
+	//embeddedImports collects imports of the files declaring the interfaces embedded from other packages
+	embeddedImports *[]*ast.ImportSpec
 }
 
 type targetProcessInput struct {
 	processInput
 	types        []*ast.TypeSpec
+	consts       []string
 	typesPrefix  string
 	imports      []*ast.ImportSpec
 	genericTypes genericTypes
 }
 
 type processOutput struct {
+	file         string
 	genericTypes genericTypes
 	methods      methodsList
 	imports      []*ast.ImportSpec
@@ -156,11 +357,45 @@ type processOutput struct {
 
 var errEmptyInterface = errors.New("interface has no methods")
 var errUnexportedMethod = errors.New("unexported method")
+var errOverwriteSource = errors.New("output file overwrites the source file")
+var errMainPackage = errors.New("main package can't be imported")
+var errIncompatibleTarget = errors.New("target interface is incompatible with the source interface")
+var errUnknownNilBaseCheck = errors.New("unknown nil base check")
+var errInvalidDecoratorName = errors.New("decorator name is not a valid identifier")
+var errNotGoOutputFile = errors.New("output file must have the .go extension")
+var errInvalidNarrowInterface = errors.New("narrow interface name is not a valid identifier")
+var errUnknownEmbedded = errors.New("primary embedded interface is not found")
+
+// StdoutFile is used as an OutputFile when the generated code is written to the standard output,
+// in this case the destination package is the one found in the current working directory
+const StdoutFile = "-"
+
+// stdoutFileName is a synthetic file name used to detect the destination package and to format
+// the generated code when it's written to the standard output
+const stdoutFileName = "gowrap_stdout.go"
+
+func (o Options) outputFile() string {
+	if o.OutputFile == StdoutFile {
+		return stdoutFileName
+	}
+
+	return o.OutputFile
+}
 
 // NewGenerator returns Generator initialized with options
 func NewGenerator(options Options) (*Generator, error) {
-	if options.Funcs == nil {
-		options.Funcs = make(template.FuncMap)
+	if strings.Contains(options.InterfaceName, ",") {
+		return newMultiGenerator(options)
+	}
+
+	options.Funcs = mergeFuncs(options.Funcs)
+
+	if err := checkFuncs("header", options.HeaderTemplate, options.Funcs); err != nil {
+		return nil, err
+	}
+
+	if err := checkFuncs("body", options.BodyTemplate, options.Funcs); err != nil {
+		return nil, err
 	}
 
 	headerTemplate, err := template.New("header").Funcs(options.Funcs).Parse(options.HeaderTemplate)
@@ -177,26 +412,61 @@ func NewGenerator(options Options) (*Generator, error) {
 		options.Vars = make(map[string]interface{})
 	}
 
-	fs := token.NewFileSet()
+	switch options.NilBaseCheck {
+	case NilBaseIgnore, NilBasePanic, NilBaseError:
+	default:
+		return nil, errors.Wrap(errUnknownNilBaseCheck, string(options.NilBaseCheck))
+	}
+
+	if options.DecoratorName != "" && !token.IsIdentifier(options.DecoratorName) {
+		return nil, errors.Wrap(errInvalidDecoratorName, options.DecoratorName)
+	}
+
+	if options.NarrowInterface != "" && !token.IsIdentifier(options.NarrowInterface) {
+		return nil, errors.Wrap(errInvalidNarrowInterface, options.NarrowInterface)
+	}
 
-	srcPackage, err := pkg.Load(options.SourcePackage)
+	//the output file is used to detect the destination package, so the path without
+	//the .go extension is ambiguous since it can be meant as the destination directory
+	if options.OutputFile != StdoutFile && filepath.Ext(options.OutputFile) != ".go" {
+		return nil, errors.Wrap(errNotGoOutputFile, options.OutputFile)
+	}
+
+	fs := options.FileSet
+	if fs == nil {
+		fs = token.NewFileSet()
+	}
+
+	srcPackage, srcPackageAST, err := loadSourcePackage(fs, options)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to load source package")
+		return nil, err
 	}
 
-	dstPackagePath := filepath.Dir(options.OutputFile)
-	if !strings.HasPrefix(dstPackagePath, "/") && !strings.HasPrefix(dstPackagePath, "./") {
+	dstPackagePath := filepath.Dir(options.outputFile())
+	if !filepath.IsAbs(dstPackagePath) && !strings.HasPrefix(dstPackagePath, "./") {
 		dstPackagePath = "./" + dstPackagePath
 	}
 
-	dstPackage, err := loadDestinationPackage(dstPackagePath)
+	if options.DestinationPackagePath != "" {
+		dstPackagePath = options.DestinationPackagePath
+	}
+
+	dstPackage, err := loadDestinationPackage(dstPackagePath, options.BuildFlags)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to load destination package: %s", dstPackagePath)
 	}
 
-	srcPackageAST, err := pkg.AST(fs, srcPackage)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse source package")
+	if options.DestinationPackagePath != "" && dstPackage.PkgPath == "" {
+		//package doesn't exist yet, the last element of the path is used as a name
+		dstPackage.PkgPath = options.DestinationPackagePath
+	}
+
+	if options.OutputPackageName != "" {
+		dstPackage.Name = options.OutputPackageName
+	}
+
+	if options.Source != nil && options.SourcePackage == "" {
+		srcPackage.PkgPath = dstPackage.PkgPath
 	}
 
 	interfaceType := srcPackage.Name + "." + options.InterfaceName
@@ -208,33 +478,98 @@ func NewGenerator(options Options) (*Generator, error) {
 			srcPackageAST.Name = options.SourcePackageAlias
 		}
 
+		if srcPackage.Name == "main" {
+			return nil, errors.Wrapf(errMainPackage, "decorator for %s should be generated into the %s package", options.InterfaceName, srcPackage.PkgPath)
+		}
+
+		if !canImport(dstPackage.PkgPath, srcPackage.PkgPath) {
+			return nil, errors.Wrapf(errInternalPackage, "%s can't be imported from %s", srcPackage.PkgPath, dstPackage.PkgPath)
+		}
+
 		options.Imports = append(options.Imports, `"`+srcPackage.PkgPath+`"`)
 	}
 
+	var embeddedImports []*ast.ImportSpec
+
 	output, err := findTarget(processInput{
-		fileSet:        fs,
-		currentPackage: srcPackage,
-		astPackage:     srcPackageAST,
-		targetName:     options.InterfaceName,
+		fileSet:         fs,
+		currentPackage:  srcPackage,
+		astPackage:      srcPackageAST,
+		targetName:      options.InterfaceName,
+		dstPackagePath:  dstPackage.PkgPath,
+		embeddedImports: &embeddedImports,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse interface declaration")
 	}
 
+	if sameFile(options.OutputFile, output.file) {
+		return nil, errors.Wrapf(errOverwriteSource, "%s declares %s", output.file, options.InterfaceName)
+	}
+
 	if len(output.methods) == 0 {
 		return nil, errEmptyInterface
 	}
 
+	if options.PrimaryEmbedded != "" {
+		output.methods, err = markPrimary(output.methods, options.PrimaryEmbedded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s doesn't embed %s", options.InterfaceName, options.PrimaryEmbedded)
+		}
+	}
+
+	output.methods, err = filterMethods(output.methods, options.IncludeMethods, options.ExcludeMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output.methods) == 0 {
+		return nil, errors.Wrap(errEmptyInterface, "all methods are filtered out by the include and exclude patterns")
+	}
+
 	for _, m := range output.methods {
 		if srcPackageAST.Name != "" && []rune(m.Name)[0] == []rune(strings.ToLower(m.Name))[0] {
 			return nil, errors.Wrap(errUnexportedMethod, m.Name)
 		}
 	}
 
+	if srcPackage.PkgPath != dstPackage.PkgPath {
+		//types of the destination package can't be qualified in the generated code
+		for _, name := range packageNames(dstPackage, output.imports) {
+			unqualifyPackage(output.methods, name)
+			unqualifyGenericTypes(output.genericTypes, name)
+		}
+	}
+
 	options.Imports = append(options.Imports, makeImports(output.imports)...)
+	options.Imports = append(options.Imports, makeImports(embeddedImports)...)
+	options.Imports = append(options.Imports, options.AdditionalImports...)
 
 	genericTypes, genericParams := output.genericTypes.buildVars()
 
+	markSelfReferences(output.methods, interfaceType+genericParams)
+
+	var targetType string
+	if options.TargetInterface != "" {
+		target, err := findTarget(processInput{
+			fileSet:        fs,
+			currentPackage: srcPackage,
+			astPackage:     srcPackageAST,
+			targetName:     options.TargetInterface,
+			dstPackagePath: dstPackage.PkgPath,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse target interface declaration")
+		}
+
+		output.methods, err = adaptMethods(output.methods, target.methods)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s can't be adapted to %s", options.InterfaceName, options.TargetInterface)
+		}
+
+		targetType = strings.TrimSuffix(interfaceType, options.InterfaceName) + options.TargetInterface
+	}
+
 	return &Generator{
 		Options:        options,
 		headerTemplate: headerTemplate,
@@ -242,6 +577,7 @@ func NewGenerator(options Options) (*Generator, error) {
 		srcPackage:     srcPackage,
 		dstPackage:     dstPackage,
 		interfaceType:  interfaceType,
+		targetType:     targetType,
 		genericTypes:   genericTypes,
 		genericParams:  genericParams,
 		methods:        output.methods,
@@ -249,152 +585,656 @@ func NewGenerator(options Options) (*Generator, error) {
 	}, nil
 }
 
-func makeImports(imports []*ast.ImportSpec) []string {
-	result := make([]string, len(imports))
-	for _, i := range imports {
-		var name string
-		if i.Name != nil {
-			name = i.Name.Name
+// markPrimary returns the methods with the Primary flag set for the ones promoted from the interface
+// directly embedded into the source one under the given name
+func markPrimary(methods methodsList, name string) (methodsList, error) {
+	found := false
+	result := make(methodsList, len(methods))
+	for methodName, m := range methods {
+		if len(m.FromEmbedded) > 0 && m.FromEmbedded[0] == name {
+			m.Primary = true
+			found = true
 		}
-		result = append(result, name+" "+i.Path.Value)
+
+		result[methodName] = m
 	}
 
-	return result
+	if !found {
+		return nil, errUnknownEmbedded
+	}
+
+	return result, nil
 }
 
-func loadDestinationPackage(path string) (*packages.Package, error) {
-	dstPackage, err := pkg.Load(path)
-	if err != nil {
-		//using directory name as a package name
-		dstPackage, err = makePackage(path)
+var errBadMethodPattern = errors.New("malformed method name pattern")
+
+// filterMethods returns the methods which names match any of the include patterns
+// and don't match any of the exclude patterns, all methods are included if include is empty
+func filterMethods(methods methodsList, include, exclude []string) (methodsList, error) {
+	filtered := make(methodsList, len(methods))
+	for name, m := range methods {
+		included, err := matchAny(name, include)
+		if err != nil {
+			return nil, err
+		}
+
+		excluded, err := matchAny(name, exclude)
+		if err != nil {
+			return nil, err
+		}
+
+		if (len(include) == 0 || included) && !excluded {
+			filtered[name] = m
+		}
 	}
 
-	return dstPackage, err
+	return filtered, nil
 }
 
-var errNoPackageName = errors.New("failed to determine the destination package name")
+// matchAny returns true if the name matches any of the glob patterns (see path.Match)
+func matchAny(name string, patterns []string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, errors.Wrap(errBadMethodPattern, pattern)
+		}
 
-func makePackage(path string) (*packages.Package, error) {
-	name := filepath.Base(path)
-	if name == string(filepath.Separator) || name == "." {
-		return nil, errNoPackageName
+		if matched {
+			return true, nil
+		}
 	}
 
-	return &packages.Package{
-		Name: name,
-	}, nil
+	return false, nil
 }
 
-// Generate generates code using header and body templates
-func (g Generator) Generate(w io.Writer) error {
-	buf := bytes.NewBuffer([]byte{})
+// adaptMethods returns the source methods that are declared in the target interface, it returns an error
+// if any of the target methods is missing in the source interface or has a different signature
+func adaptMethods(source, target methodsList) (methodsList, error) {
+	methods := make(methodsList, len(target))
+	for name, targetMethod := range target {
+		m, ok := source[name]
+		if !ok {
+			return nil, errors.Wrapf(errIncompatibleTarget, "method %s is not found", name)
+		}
 
-	err := g.headerTemplate.Execute(buf, map[string]interface{}{
-		"SourcePackage": g.srcPackage,
-		"Package":       g.dstPackage,
-		"Vars":          g.Options.Vars,
-		"Options":       g.Options,
-	})
-	if err != nil {
-		return err
+		if !sameSignature(m, targetMethod) {
+			return nil, errors.Wrapf(errIncompatibleTarget, "method %s has a different signature", name)
+		}
+
+		methods[name] = m
 	}
 
-	err = g.bodyTemplate.Execute(buf, TemplateInputs{
-		Interface: TemplateInputInterface{
-			Name: g.Options.InterfaceName,
-			Generics: TemplateInputGenerics{
-				Types:  g.genericTypes,
-				Params: g.genericParams,
-			},
-			Type:    g.interfaceType,
-			Methods: g.methods,
-		},
-		Imports: g.Options.Imports,
-		Vars:    g.Options.Vars,
-	})
-	if err != nil {
-		return err
+	return methods, nil
+}
+
+// sameSignature returns true if the methods have the same params and results types
+func sameSignature(m1, m2 Method) bool {
+	return sameTypes(m1.Params, m2.Params) && sameTypes(m1.Results, m2.Results)
+}
+
+// sameTypes compares types of the params ignoring their names
+func sameTypes(ps1, ps2 ParamsSlice) bool {
+	if len(ps1) != len(ps2) {
+		return false
 	}
 
-	imports.LocalPrefix = g.localPrefix
-	processedSource, err := imports.Process(g.Options.OutputFile, buf.Bytes(), nil)
+	for i := range ps1 {
+		if ps1[i].Type != ps2[i].Type {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSelfElem checks whether the param is a slice, an array or a map with the elements of the interfaceType
+func isSelfElem(p Param, interfaceType string) bool {
+	if p.Variadic {
+		return strings.TrimPrefix(p.Type, "...") == interfaceType
+	}
+
+	expr, err := parser.ParseExpr(p.Type)
 	if err != nil {
-		return errors.Wrapf(err, "failed to format generated code:\n%s", buf)
+		return false
 	}
 
-	_, err = w.Write(processedSource)
-	return err
+	switch t := expr.(type) {
+	case *ast.ArrayType:
+		return types.ExprString(t.Elt) == interfaceType
+	case *ast.MapType:
+		return types.ExprString(t.Value) == interfaceType
+	}
+
+	return false
 }
 
-var errTargetNotFound = errors.New("target declaration not found")
+// isSelfChan checks whether the param is a channel with the elements of the interfaceType
+func isSelfChan(p Param, interfaceType string) bool {
+	expr, err := parser.ParseExpr(p.Type)
+	if err != nil {
+		return false
+	}
 
-func findTarget(input processInput) (output processOutput, err error) {
-	ts, imports, types := iterateFiles(input.astPackage, input.targetName)
-	if ts == nil {
-		return processOutput{}, errors.Wrap(errTargetNotFound, input.targetName)
+	t, ok := expr.(*ast.ChanType)
+	return ok && types.ExprString(t.Value) == interfaceType
+}
+
+// sameFile checks whether the output file and the source file resolve to the same path
+func sameFile(outputFile, sourceFile string) bool {
+	if outputFile == StdoutFile || sourceFile == "" {
+		return false
 	}
 
-	output.imports = imports
-	output.genericTypes = buildGenericTypesFromSpec(ts)
+	outputPath, err := filepath.Abs(outputFile)
+	if err != nil {
+		return false
+	}
 
-	if it, ok := ts.Type.(*ast.InterfaceType); ok {
-		output.methods, err = processInterface(it, targetProcessInput{
-			processInput: input,
-			types:        types,
-			typesPrefix:  input.astPackage.Name,
-			imports:      output.imports,
-			genericTypes: output.genericTypes,
-		})
-		if err != nil {
-			return processOutput{}, err
-		}
+	sourcePath, err := filepath.Abs(sourceFile)
+	if err != nil {
+		return false
 	}
 
-	return
+	return outputPath == sourcePath
 }
 
-func iterateFiles(p *ast.Package, name string) (selectedType *ast.TypeSpec, imports []*ast.ImportSpec, types []*ast.TypeSpec) {
-	for _, f := range p.Files {
-		if f != nil {
-			for _, ts := range typeSpecs(f) {
-				types = append(types, ts)
-				if ts.Name.Name == name {
-					selectedType = ts
-					imports = f.Imports
-					continue
-				}
+// markSelfReferences marks params and results of the methods which type is the decorated interface itself
+// or a collection of the decorated interface values
+func markSelfReferences(methods methodsList, interfaceType string) {
+	for name, m := range methods {
+		for _, params := range []ParamsSlice{m.Params, m.Results} {
+			for i := range params {
+				params[i].Self = params[i].Type == interfaceType
+				params[i].SelfElem = isSelfElem(params[i], interfaceType)
+				params[i].SelfChan = isSelfChan(params[i], interfaceType)
 			}
 		}
+		methods[name] = m
 	}
-	return
 }
 
-func typeSpecs(f *ast.File) []*ast.TypeSpec {
-	result := []*ast.TypeSpec{}
+func makeImports(imports []*ast.ImportSpec) []string {
+	result := make([]string, 0, len(imports))
+	for _, i := range imports {
+		if i.Name == nil {
+			result = append(result, i.Path.Value)
+			continue
+		}
 
-	for _, decl := range f.Decls {
-		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
-			for _, spec := range gd.Specs {
-				if ts, ok := spec.(*ast.TypeSpec); ok {
-					result = append(result, ts)
-				}
-			}
+		//blank imports are only needed for side effects of the source package
+		if i.Name.Name == "_" {
+			continue
 		}
+		result = append(result, i.Name.Name+" "+i.Path.Value)
 	}
 
 	return result
 }
 
-func getEmbeddedMethods(t ast.Expr, pr typePrinter, input targetProcessInput) (param genericParam, methods methodsList, err error) {
-	param.Name, err = pr.PrintType(t)
+// loadSourcePackage loads and parses the source package, if the options have the Source
+// it's parsed instead and the imports of the resulting package are not loaded
+func loadSourcePackage(fs *token.FileSet, options Options) (*packages.Package, *ast.Package, error) {
+	if options.Source != nil {
+		srcPackage, srcPackageAST, err := pkg.Parse(fs, options.SourcePackage, options.Source)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse source")
+		}
+
+		return srcPackage, srcPackageAST, nil
+	}
+
+	srcPackage, err := pkg.Load(options.SourcePackage, options.BuildFlags...)
 	if err != nil {
-		return
+		return nil, nil, errors.Wrap(err, "failed to load source package")
 	}
 
-	switch v := t.(type) {
-	case *ast.SelectorExpr:
-		methods, err = processSelector(v, input)
-		return
+	srcPackageAST, err := pkg.AST(fs, srcPackage)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse source package")
+	}
+
+	return srcPackage, srcPackageAST, nil
+}
+
+func loadDestinationPackage(path string, buildFlags []string) (*packages.Package, error) {
+	dstPackage, err := pkg.Load(path, buildFlags...)
+	if err != nil {
+		//the package can't be loaded when the directory is i.e. outside of the module,
+		//so the name is taken from the package clause of the files found in the directory
+		if name := packageClause(path); name != "" {
+			return &packages.Package{Name: name}, nil
+		}
+
+		//using directory name as a package name
+		dstPackage, err = makePackage(path)
+	}
+
+	return dstPackage, err
+}
+
+// packageClause returns the package name declared by the first Go file found in the directory,
+// test files are skipped since they can declare the external test package.
+// It's empty if there are no such files
+func packageClause(dir string) string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+
+		return f.Name.Name
+	}
+
+	return ""
+}
+
+var errNoPackageName = errors.New("failed to determine the destination package name")
+
+// makePackage makes the package named after the directory, the characters that can't be
+// used in the identifiers are dropped from the name, i.e. "my-pkg" becomes "mypkg"
+func makePackage(path string) (*packages.Package, error) {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, filepath.Base(path))
+
+	if !token.IsIdentifier(name) {
+		return nil, errors.Wrap(errNoPackageName, path)
+	}
+
+	return &packages.Package{
+		Name: name,
+	}, nil
+}
+
+// Generate generates code using header and body templates
+func (g Generator) Generate(w io.Writer) error {
+	_, processedSource, err := g.generate()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(processedSource)
+	return err
+}
+
+// GenerateResult is a structured result of the code generation
+type GenerateResult struct {
+	// Source is a generated and formatted source code
+	Source []byte
+	// Imports import paths used by the generated source code
+	Imports []string
+	// Methods of the interface sorted by name
+	Methods []Method
+	// Warnings non-fatal issues found during the generation
+	Warnings []string
+}
+
+// Hash returns a hex encoded sha256 hash of the generated code. The hash only changes
+// when the generated code changes so it can be used to cache the generation results
+func (g Generator) Hash() (string, error) {
+	_, processedSource, err := g.generate()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(processedSource)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GenerateResult generates code using header and body templates and returns
+// the generated source along with the information about the generation
+func (g Generator) GenerateResult() (*GenerateResult, error) {
+	source, processedSource, err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateResult{
+		Source:  processedSource,
+		Methods: g.sortedMethods(),
+	}
+
+	result.Imports, err = importPaths(processedSource)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse generated code")
+	}
+
+	//imports that were declared by the template but were removed because they're not used
+	if declared, err := importPaths(source); err == nil {
+		used := make(map[string]bool, len(result.Imports))
+		for _, path := range result.Imports {
+			used[path] = true
+		}
+
+		for _, path := range declared {
+			if !used[path] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("unused import %q was removed", path))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (g Generator) templateInputAssert() TemplateInputAssert {
+	return TemplateInputAssert{
+		Enabled: g.Options.AssertInterface,
+		Type:    g.interfaceType,
+		Generics: TemplateInputGenerics{
+			Types:  g.genericTypes,
+			Params: g.genericParams,
+		},
+	}
+}
+
+func (g Generator) generate() (source, processedSource []byte, err error) {
+	if len(g.interfaces) > 0 {
+		return generateAll(g.interfaces)
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+
+	err = g.headerTemplate.Execute(buf, map[string]interface{}{
+		"SourcePackage": g.srcPackage,
+		"Package":       g.dstPackage,
+		"Vars":          g.Options.Vars,
+		"Options":       g.Options,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = g.bodyTemplate.Execute(buf, TemplateInputs{
+		Interface: TemplateInputInterface{
+			Name:          g.Options.InterfaceName,
+			DecoratorName: g.Options.DecoratorName,
+			Generics: TemplateInputGenerics{
+				Types:  g.genericTypes,
+				Params: g.genericParams,
+			},
+			Type:    g.interfaceType,
+			Methods: g.methods,
+			Embedding: TemplateInputEmbedding{
+				Field: g.Options.InterfaceName,
+				Type:  g.interfaceType + g.genericParams,
+			},
+			Target:          g.targetType,
+			PrimaryEmbedded: g.Options.PrimaryEmbedded,
+		},
+		Imports:     g.Options.Imports,
+		Vars:        g.Options.Vars,
+		Constructor: TemplateInputConstructor{NilBaseCheck: g.Options.NilBaseCheck},
+		Assert:      g.templateInputAssert(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if g.Options.Deprecated != "" {
+		deprecated, err := deprecate(buf.Bytes(), g.Options.Deprecated)
+		if err != nil {
+			return nil, nil, err
+		}
+		buf = bytes.NewBuffer(deprecated)
+	}
+
+	if g.Options.NarrowInterface != "" {
+		buf.WriteString(g.narrowInterface())
+	}
+
+	imports.LocalPrefix = g.localPrefix
+	processedSource, err = imports.Process(g.Options.outputFile(), buf.Bytes(), nil)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to format generated code:\n%s", buf)
+	}
+
+	if g.Options.TypeCheckOutput {
+		if err := typeCheck(g.Options.outputFile(), processedSource, g.Options.BuildFlags); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return buf.Bytes(), processedSource, nil
+}
+
+// narrowInterface returns the declaration of the interface that has only the methods passed to the templates
+func (g Generator) narrowInterface() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n// %s is the subset of the %s methods passed to the templates\n", g.Options.NarrowInterface, g.interfaceType)
+	fmt.Fprintf(&b, "type %s%s interface {\n", g.Options.NarrowInterface, g.genericTypes)
+	for _, m := range g.sortedMethods() {
+		if len(m.Doc) > 0 {
+			b.WriteString(m.DocComment() + "\n")
+		}
+		b.WriteString(m.Declaration() + "\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+var errNoTypeDecl = errors.New("generated code doesn't declare any types")
+
+// deprecate adds the Deprecated paragraph to the doc comment of the first type
+// declared in the source which is the decorator type in all templates
+func deprecate(source []byte, message string) ([]byte, error) {
+	fs := token.NewFileSet()
+	f, err := parser.ParseFile(fs, "", source, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse generated code:\n%s", source)
+	}
+
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace("// " + line)
+	}
+	lines[0] = "// Deprecated: " + strings.TrimPrefix(lines[0], "// ")
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		comment := strings.Join(lines, "\n") + "\n"
+		offset := fs.Position(gd.Pos()).Offset
+		if gd.Doc != nil {
+			//deprecation notice must be a separate paragraph of the doc comment
+			comment = "//\n" + comment
+			offset = fs.Position(gd.Doc.End()).Offset + 1
+		}
+
+		result := append([]byte{}, source[:offset]...)
+		result = append(result, comment...)
+		return append(result, source[offset:]...), nil
+	}
+
+	return nil, errNoTypeDecl
+}
+
+var errTypeCheck = errors.New("generated code doesn't compile")
+
+// typeCheck loads the destination package with the generated code put in place of the output file
+// and returns an error if the generated code has any compile errors
+func typeCheck(outputFile string, source []byte, buildFlags []string) error {
+	outputPath, err := filepath.Abs(outputFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to type check generated code")
+	}
+
+	cfg := &packages.Config{
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedSyntax,
+		Overlay:    map[string][]byte{outputPath: source},
+		BuildFlags: buildFlags,
+	}
+
+	pkgs, err := packages.Load(cfg, filepath.Dir(outputPath))
+	if err != nil {
+		return errors.Wrap(err, "failed to type check generated code")
+	}
+
+	var messages []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			//errors in other files of the destination package are not caused by the template
+			if strings.HasPrefix(e.Pos, outputPath+":") {
+				messages = append(messages, e.Error())
+			}
+		}
+	})
+
+	if len(messages) > 0 {
+		return errors.Wrap(errTypeCheck, strings.Join(messages, "\n"))
+	}
+
+	return nil
+}
+
+func (g Generator) sortedMethods() []Method {
+	methods := make([]Method, 0, len(g.methods))
+	for _, m := range g.methods {
+		methods = append(methods, m)
+	}
+
+	for _, i := range g.interfaces {
+		methods = append(methods, i.sortedMethods()...)
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+
+	return methods
+}
+
+func importPaths(source []byte) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", source, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(f.Imports))
+	for _, i := range f.Imports {
+		paths = append(paths, unquote(i.Path.Value))
+	}
+
+	return paths, nil
+}
+
+// ErrTargetNotFound is returned when the source or the target interface is not declared in the source package
+var ErrTargetNotFound = errors.New("target declaration not found")
+
+func findTarget(input processInput) (output processOutput, err error) {
+	ts, imports, types := iterateFiles(input.astPackage, input.targetName)
+	if ts == nil {
+		return processOutput{}, errors.Wrap(ErrTargetNotFound, input.targetName)
+	}
+
+	output.file = input.fileSet.Position(ts.Pos()).Filename
+	output.imports = imports
+
+	consts := constNames(input.astPackage)
+
+	pr := printer.New(input.fileSet, types, input.astPackage.Name)
+	pr.SetConsts(consts)
+
+	output.genericTypes, err = buildGenericTypesFromSpec(ts, pr)
+	if err != nil {
+		return processOutput{}, err
+	}
+
+	if it, ok := ts.Type.(*ast.InterfaceType); ok {
+		output.methods, err = processInterface(it, targetProcessInput{
+			processInput: input,
+			types:        types,
+			consts:       consts,
+			typesPrefix:  input.astPackage.Name,
+			imports:      output.imports,
+			genericTypes: output.genericTypes,
+		})
+		if err != nil {
+			return processOutput{}, err
+		}
+	}
+
+	return
+}
+
+func iterateFiles(p *ast.Package, name string) (selectedType *ast.TypeSpec, imports []*ast.ImportSpec, types []*ast.TypeSpec) {
+	for _, f := range p.Files {
+		if f != nil {
+			for _, ts := range typeSpecs(f) {
+				types = append(types, ts)
+				if ts.Name.Name == name {
+					selectedType = ts
+					imports = f.Imports
+					continue
+				}
+			}
+		}
+	}
+	return
+}
+
+func typeSpecs(f *ast.File) []*ast.TypeSpec {
+	result := []*ast.TypeSpec{}
+
+	for _, decl := range f.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+			for _, spec := range gd.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					result = append(result, ts)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func constNames(p *ast.Package) (names []string) {
+	for _, f := range p.Files {
+		if f == nil {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.CONST {
+				for _, spec := range gd.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok {
+						for _, name := range vs.Names {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func getEmbeddedMethods(t ast.Expr, pr typePrinter, input targetProcessInput) (param genericParam, methods methodsList, err error) {
+	param.Name, err = pr.PrintType(t)
+	if err != nil {
+		return
+	}
+
+	switch v := t.(type) {
+	case *ast.SelectorExpr:
+		methods, err = processSelector(v, input)
+		return
 
 	case *ast.Ident:
 		methods, err = processIdent(v, input)
@@ -405,49 +1245,71 @@ func getEmbeddedMethods(t ast.Expr, pr typePrinter, input targetProcessInput) (p
 
 func processEmbedded(t ast.Expr, pr typePrinter, input targetProcessInput) (genericParam genericParam, embeddedMethods methodsList, err error) {
 	var x ast.Expr
-	var hasGenericsParams bool
-	var genericParams genericParams
+	var indices []ast.Expr
 
 	switch v := t.(type) {
 	case *ast.IndexExpr:
-		x = v.X
-		hasGenericsParams = true
+		x, indices = v.X, []ast.Expr{v.Index}
+	case *ast.IndexListExpr:
+		x, indices = v.X, v.Indices
+	default:
+		x = v
+	}
 
-		genericParam, _, err = processEmbedded(v.Index, pr, input)
+	var genericParams genericParams
+	for _, index := range indices {
+		param, err := processTypeArg(index, pr, input)
 		if err != nil {
-			return
-		}
-		if genericParam.Name != "" {
-			genericParams = append(genericParams, genericParam)
+			return genericParam, nil, err
 		}
+		genericParams = append(genericParams, param)
+	}
+
+	input.genericParams = genericParams
+	genericParam, embeddedMethods, err = getEmbeddedMethods(x, pr, input)
+	if err != nil {
+		return
+	}
+
+	if len(indices) > 0 {
+		genericParam.Params = genericParams
+	}
 
+	return
+}
+
+// processTypeArg returns the type argument of the embedded generic interface, the type params of the embedding
+// interface are replaced with their arguments and the nested instantiations like List[T] are processed recursively
+func processTypeArg(t ast.Expr, pr typePrinter, input targetProcessInput) (param genericParam, err error) {
+	var x ast.Expr
+	var indices []ast.Expr
+
+	switch v := t.(type) {
+	case *ast.IndexExpr:
+		x, indices = v.X, []ast.Expr{v.Index}
 	case *ast.IndexListExpr:
-		x = v.X
-		hasGenericsParams = true
-
-		if v.Indices != nil {
-			for _, index := range v.Indices {
-				genericParam, _, err = processEmbedded(index, pr, input)
-				if err != nil {
-					return
-				}
-				if genericParam.Name != "" {
-					genericParams = append(genericParams, genericParam)
-				}
-			}
+		x, indices = v.X, v.Indices
+	case *ast.Ident:
+		if param.Name, err = pr.PrintType(v); err == nil {
+			param.Name = buildGenericParamsString(param.Name, input.genericTypes, input.genericParams)
 		}
+		return
 	default:
-		x = v
+		param.Name, err = pr.PrintType(v)
+		return
 	}
 
-	input.genericParams = genericParams
-	genericParam, embeddedMethods, err = getEmbeddedMethods(x, pr, input)
+	param.Name, err = pr.PrintType(x)
 	if err != nil {
 		return
 	}
 
-	if hasGenericsParams {
-		genericParam.Params = genericParams
+	for _, index := range indices {
+		indexParam, err := processTypeArg(index, pr, input)
+		if err != nil {
+			return param, err
+		}
+		param.Params = append(param.Params, indexParam)
 	}
 
 	return
@@ -461,6 +1323,7 @@ func processInterface(it *ast.InterfaceType, targetInput targetProcessInput) (me
 	methods = make(methodsList, len(it.Methods.List))
 
 	pr := printer.New(targetInput.fileSet, targetInput.types, targetInput.typesPrefix)
+	pr.SetConsts(targetInput.consts)
 
 	for _, field := range it.Methods.List {
 		var embeddedMethods methodsList
@@ -470,14 +1333,28 @@ func processInterface(it *ast.InterfaceType, targetInput targetProcessInput) (me
 		case *ast.FuncType:
 			var method *Method
 
-			method, err = NewMethod(field.Names[0].Name, field, pr, targetInput.genericTypes, targetInput.genericParams)
+			method, err = NewMethod(field.Names[0].Name, field, pr, targetInput.imports, targetInput.genericTypes, targetInput.genericParams)
 			if err == nil {
-				methods[field.Names[0].Name] = *method
+				method.imports = resolveSelectors(method.selectors, targetInput)
+				markComparable(method, field, targetInput)
+				if !method.ReturnsError && returnsErrorAlias(field, targetInput) {
+					//NewMethod always reserves the "err" name for the last result
+					method.ReturnsError = true
+					method.Results[len(method.Results)-1].Name = "err"
+				}
+
+				//the method can be embedded before it's declared directly
+				if embedded, ok := methods[method.Name]; ok && !sameSignature(embedded, *method) {
+					return nil, errors.Wrap(errDuplicateMethod, method.Name)
+				}
+
+				methods[method.Name] = *method
 				continue
 			}
 
 		default:
 			_, embeddedMethods, err = processEmbedded(v, pr, targetInput)
+			promoteMethods(embeddedMethods, embeddedName(v))
 		}
 
 		if err != nil {
@@ -493,6 +1370,213 @@ func processInterface(it *ast.InterfaceType, targetInput targetProcessInput) (me
 	return methods, nil
 }
 
+// resolveSelectors returns import paths of the packages with the given names,
+// names that can't be resolved are skipped
+func resolveSelectors(names []string, input targetProcessInput) []string {
+	var paths []string
+	for _, name := range names {
+		if path, err := findImportPathForName(name, input.imports, input.currentPackage); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// returnsErrorAlias returns true if the last result of the method is of a type declared
+// as an alias of the error interface either in the same package or in the imported one, i.e. myerr.Error
+func returnsErrorAlias(field *ast.Field, input targetProcessInput) bool {
+	ft, ok := field.Type.(*ast.FuncType)
+	if !ok || ft.Results == nil || len(ft.Results.List) == 0 {
+		return false
+	}
+
+	return isErrorAlias(ft.Results.List[len(ft.Results.List)-1].Type, aliasScope{
+		fileSet: input.fileSet,
+		pkg:     input.currentPackage,
+		types:   input.types,
+		imports: input.imports,
+	}, 0)
+}
+
+// maxAliasDepth limits the length of the aliases chain, aliases can't be cyclic
+// in the valid code but the source package isn't type checked
+const maxAliasDepth = 16
+
+// aliasScope is a package where the aliased type names are resolved
+type aliasScope struct {
+	fileSet *token.FileSet
+	pkg     *packages.Package
+	types   []*ast.TypeSpec
+	imports []*ast.ImportSpec
+}
+
+func isErrorAlias(e ast.Expr, scope aliasScope, depth int) bool {
+	if depth > maxAliasDepth {
+		return false
+	}
+
+	switch t := e.(type) {
+	case *ast.Ident:
+		for _, ts := range scope.types {
+			if ts.Name.Name == t.Name {
+				return ts.Assign.IsValid() && isErrorAlias(ts.Type, scope, depth+1)
+			}
+		}
+
+		return t.Name == "error"
+
+	case *ast.SelectorExpr:
+		scope, ok := scope.imported(t)
+		return ok && isErrorAlias(t.Sel, scope, depth+1)
+	}
+
+	return false
+}
+
+// imported returns the scope of the package referenced by the selector expression, i.e. io.Reader
+func (s aliasScope) imported(se *ast.SelectorExpr) (aliasScope, bool) {
+	x, ok := se.X.(*ast.Ident)
+	if !ok || s.pkg == nil {
+		return aliasScope{}, false
+	}
+
+	path, err := findImportPathForName(x.Name, s.imports, s.pkg)
+	if err != nil {
+		return aliasScope{}, false
+	}
+
+	p, ok := s.pkg.Imports[path]
+	if !ok {
+		return aliasScope{}, false
+	}
+
+	astPkg, err := pkg.AST(s.fileSet, p)
+	if err != nil {
+		return aliasScope{}, false
+	}
+
+	scope := aliasScope{fileSet: s.fileSet, pkg: p}
+	for _, f := range astPkg.Files {
+		scope.types = append(scope.types, typeSpecs(f)...)
+		scope.imports = append(scope.imports, f.Imports...)
+	}
+
+	return scope, true
+}
+
+// markComparable sets the Comparable flag of the method params and results
+func markComparable(method *Method, field *ast.Field, input targetProcessInput) {
+	ft, ok := field.Type.(*ast.FuncType)
+	if !ok {
+		return
+	}
+
+	scope := aliasScope{
+		fileSet: input.fileSet,
+		pkg:     input.currentPackage,
+		types:   input.types,
+		imports: input.imports,
+	}
+
+	for _, pair := range []struct {
+		fields *ast.FieldList
+		params ParamsSlice
+	}{{ft.Params, method.Params}, {ft.Results, method.Results}} {
+		if pair.fields == nil {
+			continue
+		}
+
+		i := 0
+		for _, f := range pair.fields.List {
+			//unnamed fields declare a single param
+			count := len(f.Names)
+			if count == 0 {
+				count = 1
+			}
+
+			cmp := isComparable(f.Type, scope, 0)
+			for ; count > 0; count-- {
+				pair.params[i].Comparable = cmp
+				i++
+			}
+		}
+	}
+}
+
+// isComparable returns true if the values of the type can be compared with ==,
+// slices, maps, functions and the types containing them can't be compared
+func isComparable(e ast.Expr, scope aliasScope, depth int) bool {
+	if depth > maxAliasDepth {
+		return false
+	}
+
+	switch t := e.(type) {
+	case *ast.Ident:
+		for _, ts := range scope.types {
+			if ts.Name.Name == t.Name {
+				return isComparable(ts.Type, scope, depth+1)
+			}
+		}
+
+		//predeclared types and type params
+		return true
+	case *ast.SelectorExpr:
+		scope, ok := scope.imported(t)
+		if !ok {
+			return true
+		}
+
+		return isComparable(t.Sel, scope, depth+1)
+	case *ast.ParenExpr:
+		return isComparable(t.X, scope, depth+1)
+	case *ast.IndexExpr:
+		return isComparable(t.X, scope, depth+1)
+	case *ast.IndexListExpr:
+		return isComparable(t.X, scope, depth+1)
+	case *ast.ArrayType:
+		return t.Len != nil && isComparable(t.Elt, scope, depth+1)
+	case *ast.StructType:
+		for _, f := range t.Fields.List {
+			if !isComparable(f.Type, scope, depth+1) {
+				return false
+			}
+		}
+
+		return true
+	case *ast.MapType, *ast.FuncType, *ast.Ellipsis:
+		return false
+	}
+
+	//pointers, channels and interfaces
+	return true
+}
+
+// promoteMethods prepends the name of the embedded interface to the chain of every method promoted from it
+func promoteMethods(methods methodsList, name string) {
+	for methodName, m := range methods {
+		m.FromEmbedded = append([]string{name}, m.FromEmbedded...)
+		methods[methodName] = m
+	}
+}
+
+// embeddedName returns the name of the embedded interface as it's written in the source code
+// without type params, i.e. "Reader" or "io.Reader"
+func embeddedName(e ast.Expr) string {
+	switch v := e.(type) {
+	case *ast.IndexExpr:
+		return embeddedName(v.X)
+	case *ast.IndexListExpr:
+		return embeddedName(v.X)
+	case *ast.SelectorExpr:
+		return embeddedName(v.X) + "." + v.Sel.Name
+	case *ast.Ident:
+		return v.Name
+	}
+
+	return ""
+}
+
 func processSelector(se *ast.SelectorExpr, input targetProcessInput) (methodsList, error) {
 	selectedName := se.Sel.Name
 	packageSelector := se.X.(*ast.Ident).Name
@@ -513,23 +1597,127 @@ func processSelector(se *ast.SelectorExpr, input targetProcessInput) (methodsLis
 	}
 
 	output, err := findTarget(processInput{
-		fileSet:        input.fileSet,
-		currentPackage: p,
-		astPackage:     astPkg,
-		targetName:     selectedName,
-		genericParams:  input.genericParams,
+		fileSet:         input.fileSet,
+		currentPackage:  p,
+		astPackage:      astPkg,
+		targetName:      selectedName,
+		genericParams:   input.genericParams,
+		dstPackagePath:  input.dstPackagePath,
+		embeddedImports: input.embeddedImports,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.embeddedImports != nil {
+		*input.embeddedImports = append(*input.embeddedImports, output.imports...)
+	}
+
+	if !canImport(input.dstPackagePath, p.PkgPath) && referencesPackage(output.methods, astPkg.Name) {
+		return nil, errors.Wrapf(errInternalPackage, "%s can't be imported from %s", p.PkgPath, input.dstPackagePath)
+	}
 
-	return output.methods, err
+	return output.methods, nil
 }
 
+var errInternalPackage = errors.New("use of internal package is not allowed")
+
+// canImport reports whether the package with the importerPath import path is allowed
+// to import the package with the path according to the internal packages visibility rules
+func canImport(importerPath, path string) bool {
+	if importerPath == "" {
+		//destination package is not loaded yet, nothing to check
+		return true
+	}
+
+	var parent string
+	switch {
+	case strings.HasSuffix(path, "/internal"):
+		parent = strings.TrimSuffix(path, "/internal")
+	case strings.Contains(path, "/internal/"):
+		parent = path[:strings.LastIndex(path, "/internal/")]
+	case path == "internal", strings.HasPrefix(path, "internal/"):
+		//internal packages of the standard library
+		return !strings.Contains(strings.Split(importerPath, "/")[0], ".")
+	default:
+		return true
+	}
+
+	return importerPath == parent || strings.HasPrefix(importerPath, parent+"/")
+}
+
+// referencesPackage reports whether any of the methods' params or results
+// refers to the package with the given name
+func referencesPackage(methods methodsList, name string) bool {
+	selector := packageSelectorRegexp(name)
+
+	for _, m := range methods {
+		for _, p := range append(append(ParamsSlice{}, m.Params...), m.Results...) {
+			if selector.MatchString(p.Type) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// packageNames returns the names the package is referred by in the source code,
+// i.e. the package name and the aliases it's imported with
+func packageNames(p *packages.Package, imports []*ast.ImportSpec) []string {
+	names := []string{p.Name}
+	for _, i := range imports {
+		if i.Name != nil && i.Name.Name != "_" && i.Name.Name != "." && unquote(i.Path.Value) == p.PkgPath {
+			names = append(names, i.Name.Name)
+		}
+	}
+
+	return names
+}
+
+// packageSelectorRegexp matches the package name qualifier in the type expressions
+func packageSelectorRegexp(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(name) + `\.`)
+}
+
+// unqualifyGenericTypes removes the package name qualifier from the constraints of the type params
+func unqualifyGenericTypes(types genericTypes, name string) {
+	selector := packageSelectorRegexp(name)
+
+	for i := range types {
+		types[i].Type = selector.ReplaceAllString(types[i].Type, "$1")
+	}
+}
+
+// unqualifyPackage removes the package name qualifier from the types of the methods' params and results
+func unqualifyPackage(methods methodsList, name string) {
+	selector := packageSelectorRegexp(name)
+
+	for methodName, m := range methods {
+		for _, params := range []ParamsSlice{m.Params, m.Results} {
+			for i := range params {
+				params[i].Type = selector.ReplaceAllString(params[i].Type, "$1")
+			}
+		}
+		methods[methodName] = m
+	}
+}
+
+var errDuplicateMethod = errors.New("duplicate method with a different signature")
+
 // mergeMethods merges two methods list. Retains overlapping methods from the
-// parent list
+// parent list, overlapping methods must have identical signatures
 func mergeMethods(methods, embeddedMethods methodsList) (methodsList, error) {
 	if methods == nil || embeddedMethods == nil {
 		return methods, nil
 	}
 
+	for name, m := range methods {
+		if embedded, ok := embeddedMethods[name]; ok && !sameSignature(m, embedded) {
+			return nil, errors.Wrap(errDuplicateMethod, name)
+		}
+	}
+
 	result := make(methodsList, len(methods)+len(embeddedMethods))
 	for name, signature := range embeddedMethods {
 		result[name] = signature
@@ -555,7 +1743,14 @@ func processIdent(i *ast.Ident, input targetProcessInput) (methodsList, error) {
 				return nil, errors.Wrap(errNotAnInterface, t.Name.Name)
 			}
 
-			genericsTypes = buildGenericTypesFromSpec(t)
+			pr := printer.New(input.fileSet, input.types, input.typesPrefix)
+			pr.SetConsts(input.consts)
+
+			var err error
+			genericsTypes, err = buildGenericTypesFromSpec(t, pr)
+			if err != nil {
+				return nil, err
+			}
 			break
 		}
 	}
@@ -3,8 +3,13 @@ package generator
 import (
 	"bytes"
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"text/template"
 	"time"
@@ -469,13 +474,14 @@ func Test_findTarget(t *testing.T) {
 			},
 			wantErr: true,
 			inspectErr: func(err error, t *testing.T) {
-				assert.Equal(t, errTargetNotFound, errors.Cause(err))
+				assert.Equal(t, ErrTargetNotFound, errors.Cause(err))
 			},
 		},
 		{
 			name: "found",
 			args: args{
 				input: processInput{
+					fileSet: token.NewFileSet(),
 					astPackage: &ast.Package{Files: map[string]*ast.File{
 						"file.go": {
 							Decls: []ast.Decl{&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{&ast.TypeSpec{
@@ -671,6 +677,20 @@ func TestNewGenerator(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "undefined template func",
+			options: func(t minimock.Tester) Options {
+				return Options{
+					HeaderTemplate: "",
+					BodyTemplate:   "{{ unknownFunc }}",
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUndefinedFunc, errors.Cause(err))
+				assert.Contains(t, err.Error(), `"unknownFunc"`)
+			},
+		},
 		{
 			name: "failed to load source package",
 			options: func(t minimock.Tester) Options {
@@ -773,3 +793,2026 @@ func TestNewGenerator(t *testing.T) {
 		assert.NotNil(t, g)
 	})
 }
+
+func TestNewGenerator_sourcePackageInterfaceResult(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			{{range $method := .Interface.Methods}}
+			func {{$method.Declaration}} { panic("") }
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Iface",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "source.Iface", g.interfaceType)
+	assert.Equal(t, "o1 source.OtherIface", g.methods["Sub"].Results.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `"github.com/hexdigest/gowrap/generator/testdata/source"`)
+	assert.Contains(t, buf.String(), "func Sub() (o1 source.OtherIface)")
+}
+
+func TestNewGenerator_sharedFileSet(t *testing.T) {
+	fs := token.NewFileSet()
+
+	options := Options{
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Iface",
+		FileSet:       fs,
+	}
+
+	files := func() (positions []token.Position) {
+		fs.Iterate(func(f *token.File) bool {
+			if strings.HasSuffix(f.Name(), "testdata/source/source.go") {
+				positions = append(positions, fs.Position(token.Pos(f.Base())))
+			}
+			return true
+		})
+		return
+	}
+
+	_, err := NewGenerator(options)
+	require.NoError(t, err)
+	require.Len(t, files(), 1)
+
+	base := fs.Base()
+
+	_, err = NewGenerator(options)
+	require.NoError(t, err)
+
+	positions := files()
+	require.Len(t, positions, 2)
+	assert.Equal(t, positions[0].Filename, positions[1].Filename)
+	assert.Equal(t, positions[0].Line, positions[1].Line)
+	assert.True(t, fs.Base() > base, "second generation should reuse the same file set")
+}
+
+func TestGenerator_GenerateResult(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import "fmt"}}
+			{{range $method := .Interface.Methods}}
+			func {{$method.Declaration}} { panic("") }
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Iface",
+	})
+	require.NoError(t, err)
+
+	result, err := g.GenerateResult()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(result.Source), "func Sub() (o1 source.OtherIface)")
+	assert.Equal(t, []string{"github.com/hexdigest/gowrap/generator/testdata/source"}, result.Imports)
+	require.Len(t, result.Methods, 1)
+	assert.Equal(t, "Sub", result.Methods[0].Name)
+	assert.Equal(t, []string{`unused import "fmt" was removed`}, result.Warnings)
+}
+
+func TestGenerator_Generate_embedding(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			func (d decorator) base() {{.Interface.Embedding.Type}} {
+				return d.{{.Interface.Embedding.Field}}
+			}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Iface",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), "type decorator struct {\n\tsource.Iface\n}")
+	assert.Contains(t, buf.String(), "return d.Iface")
+}
+
+func TestNewGenerator_internalPackage(t *testing.T) {
+	_, err := NewGenerator(Options{
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "WithHidden",
+	})
+	require.Error(t, err)
+	assert.Equal(t, errInternalPackage, errors.Cause(err))
+	assert.Contains(t, err.Error(), "testdata/source/internal/hidden can't be imported from github.com/hexdigest/gowrap/generator")
+}
+
+func Test_canImport(t *testing.T) {
+	tests := []struct {
+		importer string
+		path     string
+		want     bool
+	}{
+		{"a.com/b", "a.com/c", true},
+		{"a.com/b", "a.com/b/internal", true},
+		{"a.com/b/c", "a.com/b/internal/d", true},
+		{"a.com/c", "a.com/b/internal/d", false},
+		{"a.com/bc", "a.com/b/internal/d", false},
+		{"a.com/b/internal/d", "a.com/b/internal/d/internal/e", true},
+		{"a.com/b/internal/x", "a.com/b/internal/d/internal/e", false},
+		{"a.com/b", "internal/poll", false},
+		{"os", "internal/poll", true},
+		{"", "a.com/b/internal/d", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, canImport(tt.importer, tt.path), "%s imports %s", tt.importer, tt.path)
+	}
+}
+
+func TestNewGenerator_arrayLength(t *testing.T) {
+	g, err := NewGenerator(Options{
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Arrays",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "buf [16]byte", g.methods["Write"].Params.String())
+	assert.Equal(t, "buf [source.BufSize]byte", g.methods["WriteBuf"].Params.String())
+	assert.Equal(t, "buf [source.BufSize * 2 + 1]byte", g.methods["WriteHeader"].Params.String())
+}
+
+func TestNewGenerator_genericResultWithError(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `
+			type decorator{{.Interface.Generics.Types}} struct {
+				base {{.Interface.Type}}{{.Interface.Generics.Params}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator{{$.Interface.Generics.Params}}) {{$method.Declaration}} {
+				{{$method.ResultsNames}} = d.base.{{$method.Call}}
+				if err != nil {
+					var zero V
+					return zero, err
+				}
+				return
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Store",
+	})
+	require.NoError(t, err)
+
+	m := g.methods["Get"]
+	assert.True(t, m.ReturnsError)
+	assert.Equal(t, "v1, err", m.ResultsNames())
+	assert.Equal(t, "v1 V, err error", m.Results.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), "type decorator[K comparable, V any] struct {\n\tbase source.Store[K, V]\n}")
+	assert.Contains(t, buf.String(), `func (d decorator[K, V]) Get(k K) (v1 V, err error) {
+	v1, err = d.base.Get(k)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return
+}`)
+}
+
+func TestNewGenerator_genericEmbeddedConstraints(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator{{.Interface.Generics.Types}} struct {
+				base {{.Interface.Type}}{{.Interface.Generics.Params}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator{{$.Interface.Generics.Params}}) {{$method.Declaration}} {
+				{{$method.Pass "d.base."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "KeyedMeta",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[K comparable, V source.Number, M fmt.Stringer]", g.genericTypes)
+	assert.Equal(t, "[K, V, M]", g.genericParams)
+
+	assert.Equal(t, "v1 V, err error", g.methods["Get"].Results.String())
+	assert.Equal(t, "keys ...K", g.methods["Sum"].Params.String())
+	assert.Equal(t, "m1 M", g.methods["Meta"].Results.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), "type decorator[K comparable, V source.Number, M fmt.Stringer] struct {\n\tbase source.KeyedMeta[K, V, M]\n}")
+	assert.Contains(t, buf.String(), "\t\"fmt\"\n")
+}
+
+func TestNewGenerator_nestedGenericEmbedding(t *testing.T) {
+	tests := []struct {
+		name          string
+		interfaceName string
+		wantType      string
+		wantParamType string
+	}{
+		{
+			name:          "type param of the embedding interface",
+			interfaceName: "ListContainer",
+			wantType:      "type decorator[T any] struct {\n\tbase source.ListContainer[T]\n}",
+			wantParamType: "source.List[T]",
+		},
+		{
+			name:          "instantiated embedding interface",
+			interfaceName: "IntListContainer",
+			wantType:      "type decorator struct {\n\tbase source.IntListContainer\n}",
+			wantParamType: "source.List[int]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate: `{{.Import}}
+					type decorator{{.Interface.Generics.Types}} struct {
+						base {{.Interface.Type}}{{.Interface.Generics.Params}}
+					}
+
+					{{range $method := .Interface.Methods}}
+					func (d decorator{{$.Interface.Generics.Params}}) {{$method.Declaration}} {
+						{{$method.Pass "d.base."}}
+					}
+					{{end}}`,
+				SourcePackage: "./testdata/source",
+				OutputFile:    "./out.go",
+				InterfaceName: tt.interfaceName,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, "e "+tt.wantParamType, g.methods["Put"].Params.String())
+			assert.Equal(t, "e1 "+tt.wantParamType+", err error", g.methods["Get"].Results.String())
+
+			buf := bytes.NewBuffer([]byte{})
+			require.NoError(t, g.Generate(buf))
+
+			assert.Contains(t, buf.String(), tt.wantType)
+		})
+	}
+}
+
+func TestGenerator_Generate_stdout(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate:    "package {{.Package.Name}}\n",
+		BodyTemplate:      "{{.Import}}\ntype decorator struct{ {{.Interface.Type}} }",
+		SourcePackage:     "./testdata/source",
+		OutputFile:        StdoutFile,
+		OutputPackageName: "stdout",
+		InterfaceName:     "Iface",
+	})
+	require.NoError(t, err)
+
+	stdout := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(stdout))
+
+	assert.Equal(t, `package stdout
+
+import (
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)
+
+type decorator struct{ source.Iface }
+`, stdout.String())
+}
+
+func TestNewGenerator_genericSelfReference(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator{{.Interface.Generics.Types}} struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator{{$.Interface.Generics.Params}}) {{$method.Declaration}} {
+				{{$method.ResultsNames}} = d.{{$method.Call}}
+				{{- range $result := $method.Results}}
+				{{- if $result.Self}}
+				{{$result.Name}} = decorator{{$.Interface.Generics.Params}}{ {{$result.Name}} }
+				{{- end}}
+				{{- end}}
+				return
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Stream",
+	})
+	require.NoError(t, err)
+
+	m := g.methods["Map"]
+	assert.Equal(t, "f func(T) (T)", m.Params.String())
+	assert.Equal(t, "s1 source.Stream[T]", m.Results.String())
+	assert.False(t, m.Params[0].Self)
+	assert.True(t, m.Results[0].Self)
+
+	assert.False(t, g.methods["First"].Results[0].Self)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (d decorator[T]) Map(f func(T) T) (s1 source.Stream[T]) {
+	s1 = d.Map(f)
+	s1 = decorator[T]{s1}
+	return
+}`)
+}
+
+func TestNewGenerator_overwriteSource(t *testing.T) {
+	_, err := NewGenerator(Options{
+		HeaderTemplate: "package source\n",
+		BodyTemplate:   "",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./testdata/source/source.go",
+		InterfaceName:  "Iface",
+	})
+	require.Error(t, err)
+	assert.Equal(t, errOverwriteSource, errors.Cause(err))
+}
+
+func Test_sameFile(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	assert.True(t, sameFile("./testdata/source/source.go", filepath.Join(wd, "testdata", "source", "source.go")))
+	assert.True(t, sameFile("testdata/../testdata/source/source.go", filepath.Join(wd, "testdata", "source", "source.go")))
+	assert.False(t, sameFile("./testdata/source/out.go", filepath.Join(wd, "testdata", "source", "source.go")))
+	assert.False(t, sameFile(StdoutFile, filepath.Join(wd, StdoutFile)))
+	assert.False(t, sameFile("./out.go", ""))
+}
+
+func TestNewGenerator_fromEmbedded(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Outer",
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, g.methods["Name"].FromEmbedded)
+	assert.Equal(t, []string{"Middle"}, g.methods["Flush"].FromEmbedded)
+	assert.Equal(t, []string{"Middle", "Inner"}, g.methods["Read"].FromEmbedded)
+	assert.Equal(t, []string{"Middle", "io.Closer"}, g.methods["Close"].FromEmbedded)
+}
+
+func TestGenerator_Generate_deferResults(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+				after func(string)
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Defer "d.after"}}
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "OtherIface",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (d decorator) Name() (s1 string) {
+	defer func() {
+		d.after(s1)
+	}()
+	return d.Name()
+}`)
+}
+
+func TestNewGenerator_stdlibPackage(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "sort",
+		OutputFile:    "./out.go",
+		InterfaceName: "Interface",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import "sort"`)
+	assert.Contains(t, buf.String(), "\tsort.Interface\n")
+	assert.Contains(t, buf.String(), "func (d decorator) Less(i int, j int) (b1 bool) {")
+}
+
+func TestNewGenerator_comparableParams(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Finder",
+	})
+	require.NoError(t, err)
+
+	comparable := func(params ParamsSlice) map[string]bool {
+		m := make(map[string]bool, len(params))
+		for _, p := range params {
+			m[p.Name] = p.Comparable
+		}
+		return m
+	}
+
+	assert.Equal(t, map[string]bool{"key": true, "filter": true, "ch": true, "names": true, "u": true}, comparable(g.methods["Find"].Params))
+	assert.Equal(t, map[string]bool{"k1": true, "err": true}, comparable(g.methods["Find"].Results))
+	assert.Equal(t, map[string]bool{"keys": false, "filter": false, "query": false, "fn": false, "m": false, "ids": false}, comparable(g.methods["FindAll"].Params))
+}
+
+func TestGenerator_Generate_receiverNamedParams(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type receiverDecorator struct {
+				_base {{.Interface.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (_d receiverDecorator) {{$method.Declaration}} {
+				{{$method.ResultsNames}} = _d._base.{{$method.Call}}
+				return {{$method.ResultsNames}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Decoder",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (_d receiverDecorator) Decode(ba1 []byte) (d int, err error) {
+	d, err = _d._base.Decode(ba1)`)
+	assert.Contains(t, buf.String(), `func (_d receiverDecorator) Split(d []byte) (ba1 []byte, rest []byte) {
+	ba1, rest = _d._base.Split(d)`)
+	assert.NotContains(t, buf.String(), "_d []byte", "the params and results must not shadow the receiver")
+}
+
+func TestGenerator_Generate_typeCheckOutput(t *testing.T) {
+	options := Options{
+		HeaderTemplate:  "package generator\n",
+		SourcePackage:   "./testdata/source",
+		OutputFile:      "./out.go",
+		InterfaceName:   "OtherIface",
+		TypeCheckOutput: true,
+	}
+
+	t.Run("valid code", func(t *testing.T) {
+		options := options
+		options.BodyTemplate = `{{.Import}}
+			type typeCheckDecorator struct {
+				{{.Interface.Embedding.Type}}
+			}`
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		assert.NoError(t, g.Generate(io.Discard))
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		options := options
+		options.BodyTemplate = `
+			type typeCheckDecorator struct {}
+
+			func (d typeCheckDecorator) Name() string {
+				return d.name
+			}`
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		err = g.Generate(io.Discard)
+		require.Error(t, err)
+		assert.Equal(t, errTypeCheck, errors.Cause(err))
+		assert.Contains(t, err.Error(), "out.go:6:11: d.name undefined")
+	})
+}
+
+func TestNewGenerator_constraintEmbedding(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Constrained",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, g.methods, 1)
+	assert.Equal(t, "i1 int", g.methods["Measure"].Results.String())
+}
+
+func TestGenerator_Generate_unnamedResultsOrder(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.ResultsNames}} = d.{{$.Interface.Embedding.Field}}.{{$method.Call}}
+				return {{$method.ResultsNames}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Point",
+	})
+	require.NoError(t, err)
+
+	m := g.methods["Coords"]
+	assert.Equal(t, "i1 int, i2 int", m.Results.String())
+	assert.Equal(t, "i1, i2", m.ResultsNames())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (d decorator) Coords() (i1 int, i2 int) {
+	i1, i2 = d.Point.Coords()
+	return i1, i2
+}`)
+}
+
+func TestGenerator_Generate_blankImport(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Blank",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "r io.Reader", g.methods["Copy"].Params.String())
+	assert.Equal(t, []string{"io.Closer"}, g.methods["Close"].FromEmbedded)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"io"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.NotContains(t, buf.String(), `_ "io"`)
+}
+
+func TestGenerator_Generate_inlineInterface(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Opener",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"io"}, methodImports(g.methods["Do"]))
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"io"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.Contains(t, buf.String(), `func (d decorator) Do(r interface {
+	io.Reader
+	Name() string
+}) (err error) {`)
+	assert.Contains(t, buf.String(), `func (d decorator) Wrap(n interface {
+	source.Named
+	Close() error
+}) (p1 interface {
+	io.Closer
+}) {`)
+}
+
+func TestNewGenerator_mainPackage(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package {{.Package.Name}}\n",
+		BodyTemplate: `
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}`,
+		SourcePackage: "./testdata/mainpkg",
+		InterfaceName: "Service",
+	}
+
+	t.Run("same package", func(t *testing.T) {
+		options := options
+		options.OutputFile = "./testdata/mainpkg/out.go"
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+
+		assert.Equal(t, "package main\n\ntype decorator struct {\n\tService\n}\n", buf.String())
+	})
+
+	t.Run("another package", func(t *testing.T) {
+		options := options
+		options.OutputFile = "./out.go"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errMainPackage, errors.Cause(err))
+	})
+}
+
+func TestGenerator_Generate_additionalImports(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			var _ aliased.Key`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Iface",
+		AdditionalImports: []string{
+			`aliased "github.com/hexdigest/gowrap/generator/testdata/other"`,
+			`"bytes"`,
+		},
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Equal(t, `package generator
+
+import (
+	aliased "github.com/hexdigest/gowrap/generator/testdata/other"
+)
+
+var _ aliased.Key
+`, buf.String())
+}
+
+func TestTemplateInputs_Import(t *testing.T) {
+	inputs := TemplateInputs{Imports: []string{` "context" `, ""}}
+
+	assert.Equal(t, "import (\n\t\"context\"\n\t\"fmt\"\n\t\"io\"\n)\n", inputs.Import(" fmt ", `"io"`, "", " ", "context"))
+	assert.Equal(t, "", TemplateInputs{}.Import("", " "))
+
+	inputs = TemplateInputs{Imports: []string{`"fmt"`, ` "fmt"`, `enc  "encoding/json"`, `enc "encoding/json"`}}
+	assert.Equal(t, "import (\n\t\"fmt\"\n\tenc \"encoding/json\"\n)\n", inputs.Import("fmt", `enc "encoding/json"`))
+}
+
+func Test_makeImports(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", `package p
+
+import (
+	"context"
+	"fmt"
+	enc "encoding/json"
+	. "strings"
+	_ "embed"
+)
+`, parser.ImportsOnly)
+	require.NoError(t, err)
+
+	//there must be no empty imports before the listed ones
+	assert.Equal(t, []string{`"context"`, `"fmt"`, `enc "encoding/json"`, `. "strings"`}, makeImports(f.Imports))
+}
+
+func TestNewGenerator_imports(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{.Import}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Fetcher",
+	})
+	require.NoError(t, err)
+
+	seen := map[string]bool{}
+	for _, i := range g.Options.Imports {
+		assert.Equal(t, strings.TrimSpace(i), i)
+		assert.NotEmpty(t, i)
+		assert.False(t, seen[i], "duplicate import %s", i)
+		seen[i] = true
+	}
+
+	assert.Equal(t, "import (\n\t\"context\"\n\t\"github.com/hexdigest/gowrap/generator/testdata/source\"\n\t\"net/url\"\n)\n", TemplateInputs{Imports: g.Options.Imports}.Import("context"))
+}
+
+func TestGenerator_Generate_importHelper(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import "fmt" " " ""}}
+			var _ = fmt.Sprint`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Point",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Equal(t, `package generator
+
+import (
+	"fmt"
+)
+
+var _ = fmt.Sprint
+`, buf.String())
+}
+
+func TestNewGenerator_selfReferenceElem(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Tree",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		method   string
+		param    Param
+		self     bool
+		selfElem bool
+	}{
+		{method: "Parent", param: g.methods["Parent"].Results[0], self: true},
+		{method: "Children", param: g.methods["Children"].Results[0], selfElem: true},
+		{method: "Path", param: g.methods["Path"].Results[0], selfElem: true},
+		{method: "ByName", param: g.methods["ByName"].Results[0], selfElem: true},
+		{method: "Add", param: g.methods["Add"].Params[0], selfElem: true},
+		{method: "Names", param: g.methods["Names"].Results[0]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			assert.Equal(t, tt.self, tt.param.Self)
+			assert.Equal(t, tt.selfElem, tt.param.SelfElem)
+		})
+	}
+}
+
+func TestNewGenerator_selfReferenceChan(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				base {{.Interface.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d.base."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Watcher",
+	})
+	require.NoError(t, err)
+
+	watch := g.methods["Watch"]
+	assert.True(t, watch.ReturnsError)
+	assert.Equal(t, "ch1 <-chan source.Watcher, err error", watch.Results.String())
+	assert.True(t, watch.Results[0].SelfChan)
+	assert.False(t, watch.Results[0].Self)
+	assert.False(t, watch.Results[0].SelfElem)
+	assert.False(t, watch.Results[1].SelfChan)
+
+	assert.True(t, g.methods["Subscribe"].Params[0].SelfChan)
+	assert.False(t, g.methods["Events"].Results[0].SelfChan)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (d decorator) Watch() (ch1 <-chan source.Watcher, err error) {
+	return d.base.Watch()
+}`)
+}
+
+func TestNewGenerator_targetInterface(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type adapter struct {
+				base {{.Interface.Type}}
+			}
+
+			var _ {{.Interface.Target}} = adapter{}
+
+			{{range $method := .Interface.Methods}}
+			func (a adapter) {{$method.Declaration}} {
+				{{$method.Pass "a.base."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "ReadWriter",
+	}
+
+	t.Run("compatible target", func(t *testing.T) {
+		options := options
+		options.TargetInterface = "Reader"
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+
+		assert.Contains(t, buf.String(), "var _ source.Reader = adapter{}")
+		assert.Contains(t, buf.String(), "func (a adapter) Read(p []byte) (n int, err error) {\n\treturn a.base.Read(p)\n}")
+		assert.NotContains(t, buf.String(), ") Write(")
+	})
+
+	for _, target := range []string{"BrokenReader", "Seeker"} {
+		t.Run("incompatible target "+target, func(t *testing.T) {
+			options := options
+			options.TargetInterface = target
+
+			_, err := NewGenerator(options)
+			require.Error(t, err)
+			assert.Equal(t, errIncompatibleTarget, errors.Cause(err))
+		})
+	}
+}
+
+func TestGenerator_Generate_destinationPackageSelector(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package {{.Package.Name}}\n",
+		BodyTemplate: `
+			type impl struct{}
+
+			{{range $method := .Interface.Methods}}
+			func (impl) {{$method.Declaration}} {
+				return
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./testdata/source/internal/hidden/out.go",
+		InterfaceName: "WithHidden",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1 Value", g.methods["Get"].Results.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Equal(t, `package hidden
+
+type impl struct{}
+
+func (impl) Get() (v1 Value) {
+	return
+}
+`, buf.String())
+}
+
+func Test_unqualifyPackage(t *testing.T) {
+	methods := methodsList{"M": Method{
+		Params:  ParamsSlice{{Name: "m", Type: "map[dst.Key][]*dst.Value"}, {Name: "o", Type: "otherdst.Value"}},
+		Results: ParamsSlice{{Name: "f", Type: "func(dst.Key) (dst.Value)"}},
+	}}
+
+	unqualifyPackage(methods, "dst")
+
+	assert.Equal(t, "m map[Key][]*Value, o otherdst.Value", methods["M"].Params.String())
+	assert.Equal(t, "f func(Key) (Value)", methods["M"].Results.String())
+}
+
+func TestGenerator_Hash(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			// {{.Vars.DecoratorName}} decorates {{.Interface.Type}}
+			type {{.Vars.DecoratorName}} struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d {{$.Vars.DecoratorName}}) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Tree",
+		Vars:          map[string]interface{}{"DecoratorName": "TreeDecorator"},
+	}
+
+	g, err := NewGenerator(options)
+	require.NoError(t, err)
+
+	hash1, err := g.Hash()
+	require.NoError(t, err)
+	assert.Len(t, hash1, 64)
+
+	hash2, err := g.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash1, hash2)
+
+	options.Vars = map[string]interface{}{"DecoratorName": "AnotherTreeDecorator"}
+	g, err = NewGenerator(options)
+	require.NoError(t, err)
+
+	hash3, err := g.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash1, hash3)
+}
+
+func TestGenerator_Generate_aliasedImport(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Aliased",
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	enc "encoding/json"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.Contains(t, buf.String(), "func (d decorator) Raw() (r1 enc.RawMessage) {")
+}
+
+func TestGenerator_Generate_nestedQualifiedTypes(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Nested",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "m map[string]map[time.Month][]*url.URL", g.methods["Set"].Params.String())
+	assert.Equal(t, "m map[source.Key]map[time.Weekday]chan []source.Key", g.methods["SetLocal"].Params.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"net/url"
+	"time"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+}
+
+func TestGenerator_Generate_anyMapValue(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "AnyMap",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "key other.Key", g.methods["Cache"].Params.String())
+	assert.Equal(t, "m1 map[other.Key]any", g.methods["Cache"].Results.String())
+	assert.Equal(t, "m map[other.Key]any", g.methods["Fill"].Params.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"github.com/hexdigest/gowrap/generator/testdata/other"
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.Contains(t, buf.String(), "func (d decorator) Cache(key other.Key) (m1 map[other.Key]any) {")
+	assert.Contains(t, buf.String(), "func (d decorator) Fill(m map[other.Key]any) (err error) {")
+}
+
+func TestGenerator_Generate_methodNamedAsHelper(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import "fmt"}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			// {{.Interface.Methods.Import.Name}} is decorated, {{Import .Interface.Methods.Snake.Name}} is not
+			func (d decorator) {{(index .Interface.Methods "Import").Declaration}} {
+				fmt.Println({{quote .Interface.Methods.Import.Name}})
+				{{.Interface.Methods.Import.Pass (printf "d.%s." .Interface.Embedding.Field)}}
+			}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Importer",
+		Funcs:         template.FuncMap{"quote": strconv.Quote, "Import": strings.ToLower},
+	})
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"fmt"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.Contains(t, buf.String(), `// Import is decorated, snake is not
+func (d decorator) Import(path string) (err error) {
+	fmt.Println("Import")
+	return d.Importer.Import(path)
+}`)
+}
+
+func TestGenerator_Generate_embeddedInterfaceImports(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "NestedEmbedded",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "m map[string]map[enc.Number][]enc.RawMessage", g.methods["SetRaw"].Params.String())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	enc "encoding/json"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+}
+
+func TestNewGenerator_destinationPackagePath(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package {{.Package.Name}}\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "OtherIface",
+	}
+
+	t.Run("source package", func(t *testing.T) {
+		options := options
+		options.DestinationPackagePath = "github.com/hexdigest/gowrap/generator/testdata/source"
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+
+		assert.Equal(t, "package source\n\ntype decorator struct {\n\tOtherIface\n}\n", buf.String())
+	})
+
+	t.Run("package that doesn't exist yet", func(t *testing.T) {
+		options := options
+		options.DestinationPackagePath = "github.com/hexdigest/gowrap/generator/moved"
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+
+		assert.Equal(t, `package moved
+
+import (
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)
+
+type decorator struct {
+	source.OtherIface
+}
+`, buf.String())
+	})
+}
+
+func TestGenerator_Generate_stringer(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+				calls int
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d *decorator) {{$method.Declaration}} {
+				{{- if not $method.IsStringer}}
+				d.calls++
+				{{- end}}
+				{{$method.Pass (printf "d.%s." $.Interface.Embedding.Field)}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Named",
+	})
+	require.NoError(t, err)
+
+	m := g.methods["String"]
+	assert.True(t, m.IsStringer())
+	assert.Equal(t, []string{"fmt.Stringer"}, m.FromEmbedded)
+	assert.False(t, g.methods["Rename"].IsStringer())
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `func (d *decorator) Rename(name string) (err error) {
+	d.calls++
+	return d.Named.Rename(name)
+}`)
+	assert.Contains(t, buf.String(), `func (d *decorator) String() (s1 string) {
+	return d.Named.String()
+}`)
+}
+
+const nilBaseCheckTemplate = `{{.Import}}
+	type decorator struct {
+		{{.Interface.Embedding.Type}}
+	}
+
+	func newDecorator(base {{.Interface.Embedding.Type}}) {{.Constructor.Results "*decorator"}} {
+		{{.Constructor.CheckBase "base" "*decorator"}}
+		{{.Constructor.Return "&decorator{base}"}}
+	}`
+
+func TestGenerator_Generate_nilBaseCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		check NilBaseCheck
+		want  string
+	}{
+		{
+			name:  "ignore",
+			check: NilBaseIgnore,
+			want: `func newDecorator(base source.Iface) *decorator {
+
+	return &decorator{base}
+}`,
+		},
+		{
+			name:  "panic",
+			check: NilBasePanic,
+			want: `func newDecorator(base source.Iface) *decorator {
+	if base == nil {
+		panic("decorator: base implementation is nil")
+	}
+	return &decorator{base}
+}`,
+		},
+		{
+			name:  "error",
+			check: NilBaseError,
+			want: `func newDecorator(base source.Iface) (*decorator, error) {
+	if base == nil {
+		return nil, errors.New("decorator: base implementation is nil")
+	}
+	return &decorator{base}, nil
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   nilBaseCheckTemplate,
+				SourcePackage:  "./testdata/source",
+				OutputFile:     "./out.go",
+				InterfaceName:  "Iface",
+				NilBaseCheck:   tt.check,
+			})
+			require.NoError(t, err)
+
+			buf := bytes.NewBuffer([]byte{})
+			require.NoError(t, g.Generate(buf))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestNewGenerator_unknownNilBaseCheck(t *testing.T) {
+	_, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   nilBaseCheckTemplate,
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Iface",
+		NilBaseCheck:   "log",
+	})
+	require.Error(t, err)
+	assert.Equal(t, errUnknownNilBaseCheck, errors.Cause(err))
+}
+
+func TestGenerator_Generate_assertInterface(t *testing.T) {
+	tests := []struct {
+		name          string
+		interfaceName string
+		assert        bool
+		want          string
+	}{
+		{
+			name:          "disabled",
+			interfaceName: "Iface",
+			want:          "type decorator struct {\n\tsource.Iface\n}\n",
+		},
+		{
+			name:          "interface",
+			interfaceName: "Iface",
+			assert:        true,
+			want:          "var _ source.Iface = (*decorator)(nil)",
+		},
+		{
+			name:          "generic interface",
+			interfaceName: "Store",
+			assert:        true,
+			want:          "func _[K comparable, V any]() {\n\tvar _ source.Store[K, V] = (*decorator[K, V])(nil)\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate: `{{.Import}}
+					type decorator{{.Interface.Generics.Types}} struct {
+						{{.Interface.Embedding.Type}}
+					}
+
+					{{.Assert.Implements "decorator"}}`,
+				SourcePackage:   "./testdata/source",
+				OutputFile:      "./out.go",
+				InterfaceName:   tt.interfaceName,
+				AssertInterface: tt.assert,
+			})
+			require.NoError(t, err)
+
+			buf := bytes.NewBuffer([]byte{})
+			require.NoError(t, g.Generate(buf))
+			assert.Contains(t, buf.String(), tt.want)
+			if !tt.assert {
+				assert.NotContains(t, buf.String(), "var _")
+			}
+		})
+	}
+}
+
+func TestNewGenerator_decoratorName(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   `type {{or .Interface.DecoratorName "decorator"}} struct{ {{.Interface.Type}} }`,
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Iface",
+	}
+
+	t.Run("default name", func(t *testing.T) {
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.Contains(t, buf.String(), "type decorator struct{ source.Iface }")
+	})
+
+	t.Run("custom name", func(t *testing.T) {
+		options := options
+		options.DecoratorName = "IfaceDecorator"
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.Contains(t, buf.String(), "type IfaceDecorator struct{ source.Iface }")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		options := options
+		options.DecoratorName = "func"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errInvalidDecoratorName, errors.Cause(err))
+	})
+}
+
+func TestNewGenerator_outputFileExtension(t *testing.T) {
+	tests := []struct {
+		name       string
+		outputFile string
+		wantErr    error
+	}{
+		{name: "go file", outputFile: "./out.go"},
+		{name: "stdout", outputFile: StdoutFile},
+		{name: "no extension", outputFile: "./out", wantErr: errNotGoOutputFile},
+		{name: "directory", outputFile: "./decorators/", wantErr: errNotGoOutputFile},
+		{name: "other extension", outputFile: "./out.txt", wantErr: errNotGoOutputFile},
+		{name: "dotted directory", outputFile: "./decorators/v1.2", wantErr: errNotGoOutputFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   "{{.Import}}",
+				SourcePackage:  "./testdata/source",
+				OutputFile:     tt.outputFile,
+				InterfaceName:  "Iface",
+			})
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, errors.Cause(err))
+			assert.Equal(t, tt.outputFile+": output file must have the .go extension", err.Error())
+		})
+	}
+}
+
+func TestNewGenerator_absolutePaths(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package {{.Package.Name}}\n",
+		BodyTemplate:   "{{.Import}}\nvar _ {{.Interface.Type}}",
+		SourcePackage:  filepath.Join(wd, "testdata", "source"),
+		OutputFile:     filepath.Join(wd, "out.go"),
+		InterfaceName:  "Iface",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "generator", g.dstPackage.Name)
+	assert.Equal(t, "source.Iface", g.interfaceType)
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+	assert.Contains(t, buf.String(), `"github.com/hexdigest/gowrap/generator/testdata/source"`)
+}
+
+func TestGenerator_Generate_deprecated(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		deprecated string
+		want       string
+	}{
+		{
+			name:       "no doc comment",
+			body:       "{{.Import}}\ntype decorator struct {\n{{.Interface.Embedding.Type}}\n}",
+			deprecated: "use another decorator",
+			want:       "// Deprecated: use another decorator\ntype decorator struct {",
+		},
+		{
+			name:       "doc comment",
+			body:       "{{.Import}}\nconst name = \"decorator\"\n\n// decorator decorates {{.Interface.Type}}\ntype decorator struct {\n{{.Interface.Embedding.Type}}\n}",
+			deprecated: "use another decorator\nit will be removed soon",
+			want:       "// decorator decorates source.Iface\n//\n// Deprecated: use another decorator\n// it will be removed soon\ntype decorator struct {",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   tt.body,
+				SourcePackage:  "./testdata/source",
+				OutputFile:     "./out.go",
+				InterfaceName:  "Iface",
+				Deprecated:     tt.deprecated,
+			})
+			require.NoError(t, err)
+
+			buf := bytes.NewBuffer([]byte{})
+			require.NoError(t, g.Generate(buf))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func Test_deprecate_noTypeDecl(t *testing.T) {
+	_, err := deprecate([]byte("package generator\n\nfunc f() {}\n"), "use g")
+	assert.Equal(t, errNoTypeDecl, err)
+}
+
+func TestNewGenerator_errorAlias(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{.Import}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Validator",
+	})
+	require.NoError(t, err)
+
+	validate := g.methods["Validate"]
+	assert.True(t, validate.ReturnsError)
+	assert.Equal(t, "err source.Failure", validate.Results.String())
+
+	check := g.methods["Check"]
+	assert.True(t, check.ReturnsError)
+	assert.Equal(t, "b1 bool, err source.Reason", check.Results.String())
+
+	verify := g.methods["Verify"]
+	assert.True(t, verify.ReturnsError)
+	assert.Equal(t, "ok bool, err other.Error", verify.Results.String())
+
+	inspect := g.methods["Inspect"]
+	assert.True(t, inspect.ReturnsError)
+	assert.Equal(t, "err source.Problem", inspect.Results.String())
+}
+
+func TestNewGenerator_filterMethods(t *testing.T) {
+	tests := []struct {
+		name        string
+		include     []string
+		exclude     []string
+		wantMethods []string
+		wantErr     error
+	}{
+		{
+			name:        "no patterns",
+			wantMethods: []string{"Add", "ByName", "Children", "Names", "Parent", "Path"},
+		},
+		{
+			name:        "include",
+			include:     []string{"P*", "Add"},
+			wantMethods: []string{"Add", "Parent", "Path"},
+		},
+		{
+			name:        "exclude",
+			exclude:     []string{"*ren*"},
+			wantMethods: []string{"Add", "ByName", "Names", "Path"},
+		},
+		{
+			name:        "exclusion wins",
+			include:     []string{"P*"},
+			exclude:     []string{"Parent"},
+			wantMethods: []string{"Path"},
+		},
+		{
+			name:    "everything is filtered out",
+			include: []string{"Get*"},
+			wantErr: errEmptyInterface,
+		},
+		{
+			name:    "malformed pattern",
+			exclude: []string{"[P"},
+			wantErr: errBadMethodPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   "{{.Import}}",
+				SourcePackage:  "./testdata/source",
+				OutputFile:     "./out.go",
+				InterfaceName:  "Tree",
+				IncludeMethods: tt.include,
+				ExcludeMethods: tt.exclude,
+			})
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, errors.Cause(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, m := range g.sortedMethods() {
+				names = append(names, m.Name)
+			}
+			assert.Equal(t, tt.wantMethods, names)
+		})
+	}
+}
+
+func Test_loadDestinationPackage(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "package clause",
+			files: map[string]string{"doc.go": "// Package mypkg is a test package\npackage mypkg\n"},
+			want:  "mypkg",
+		},
+		{
+			name: "test files are skipped",
+			files: map[string]string{
+				"a_test.go": "package mypkg_test\n",
+				"b.go":      "package mypkg\n",
+			},
+			want: "mypkg",
+		},
+		{
+			name:  "malformed files are skipped",
+			files: map[string]string{"a.go": "packag mypkg\n", "b.go": "package mypkg\n"},
+			want:  "mypkg",
+		},
+		{
+			name: "no files",
+			want: "mypkg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//the directory is outside of the module so the package can't be loaded
+			dir := filepath.Join(t.TempDir(), "my-pkg")
+			require.NoError(t, os.Mkdir(dir, os.ModePerm))
+
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0664))
+			}
+
+			p, err := loadDestinationPackage(dir, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.Name)
+		})
+	}
+}
+
+func Test_makePackage(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "/tmp/decorators", want: "decorators"},
+		{path: "/tmp/go-yaml.v3", want: "goyamlv3"},
+		{path: "/tmp/1st", wantErr: true},
+		{path: "/tmp/type", wantErr: true},
+		{path: "/tmp/---", wantErr: true},
+		{path: string(filepath.Separator), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := makePackage(tt.path)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, errNoPackageName, errors.Cause(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.Name)
+		})
+	}
+}
+
+func TestNewGenerator_primaryEmbedded(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			// decorator decorates the methods of {{.Interface.Type}} except the ones of {{.Interface.PrimaryEmbedded}}
+			type decorator struct {
+				_base {{.Interface.Type}}
+			}
+
+			{{.Assert.Implements "decorator"}}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{- if not $method.Undecorated}}
+				// decorated
+				{{- end}}
+				{{$method.Pass "d._base."}}
+			}
+			{{end}}`,
+		SourcePackage:   "./testdata/source",
+		OutputFile:      "./out.go",
+		InterfaceName:   "Middle",
+		PrimaryEmbedded: "io.Closer",
+		AssertInterface: true,
+		TypeCheckOutput: true,
+	}
+
+	t.Run("primary methods are forwarded", func(t *testing.T) {
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		primary := map[string]bool{}
+		for _, m := range g.sortedMethods() {
+			primary[m.Name] = m.Primary
+		}
+		assert.Equal(t, map[string]bool{"Close": true, "Flush": false, "Read": false}, primary)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.Contains(t, buf.String(), "// decorator decorates the methods of source.Middle except the ones of io.Closer\n")
+		assert.Contains(t, buf.String(), "func (d decorator) Close() (err error) {\n\treturn d._base.Close()\n}")
+		assert.Contains(t, buf.String(), "func (d decorator) Flush() (err error) {\n\t// decorated\n\treturn d._base.Flush()\n}")
+	})
+
+	t.Run("unknown embedded interface", func(t *testing.T) {
+		options := options
+		options.PrimaryEmbedded = "Closer"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errUnknownEmbedded, errors.Cause(err))
+	})
+
+	t.Run("nested embedded interface", func(t *testing.T) {
+		options := options
+		options.InterfaceName = "Outer"
+		options.PrimaryEmbedded = "Inner"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errUnknownEmbedded, errors.Cause(err))
+	})
+}
+
+func TestNewGenerator_directives(t *testing.T) {
+	options := Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			{{if $method.Doc}}{{$method.DocComment}}{{end}}
+			func (d decorator) {{$method.Declaration}} {
+				{{- if not $method.Directives.skip}}
+					println("{{$method.Name}}")
+				{{- end}}
+				{{$method.Pass (printf "d.%s." $.Interface.Embedding.Field)}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Directed",
+	}
+
+	t.Run("directives", func(t *testing.T) {
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"skip": "true"}, g.methods["Get"].Directives)
+		assert.Equal(t, []string{"// Get returns the value."}, g.methods["Get"].Doc)
+		assert.Equal(t, "Get returns the value.\n", g.methods["Get"].DocText)
+
+		assert.Equal(t, map[string]string{"template": "passthrough", "timeout": "5s"}, g.methods["Set"].Directives)
+		assert.Empty(t, g.methods["Set"].Doc)
+		assert.Empty(t, g.methods["Set"].DocText)
+
+		assert.Nil(t, g.methods["Len"].Directives)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.Contains(t, buf.String(), "// Get returns the value.\nfunc (d decorator) Get(key string) (s1 string, err error) {\n\treturn d.Directed.Get(key)\n}")
+		assert.Contains(t, buf.String(), "func (d decorator) Set(key string, value string) (err error) {\n\tprintln(\"Set\")")
+		assert.NotContains(t, buf.String(), "gowrap:")
+	})
+
+	t.Run("malformed directive", func(t *testing.T) {
+		options := options
+		options.InterfaceName = "Misdirected"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errMalformedDirective, errors.Cause(err))
+		assert.Contains(t, err.Error(), "Get: // gowrap:skip it: malformed gowrap directive")
+	})
+}
+
+func TestNewGenerator_narrowInterface(t *testing.T) {
+	options := Options{
+		HeaderTemplate:  "package generator\n",
+		BodyTemplate:    `type {{or .Interface.DecoratorName "decorator"}} struct{ {{.Interface.Type}} }`,
+		SourcePackage:   "./testdata/source",
+		OutputFile:      "./out.go",
+		InterfaceName:   "Tree",
+		IncludeMethods:  []string{"P*"},
+		NarrowInterface: "PathTree",
+	}
+
+	t.Run("narrowed methods", func(t *testing.T) {
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.Contains(t, buf.String(), "type decorator struct{ source.Tree }")
+		assert.Contains(t, buf.String(), `// PathTree is the subset of the source.Tree methods passed to the templates
+type PathTree interface {
+	Parent() (t1 source.Tree)
+	Path() (ta1 [2]source.Tree)
+}
+`)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		options := options
+		options.NarrowInterface = ""
+
+		g, err := NewGenerator(options)
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer([]byte{})
+		require.NoError(t, g.Generate(buf))
+		assert.NotContains(t, buf.String(), "interface {")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		options := options
+		options.NarrowInterface = "Path-Tree"
+
+		_, err := NewGenerator(options)
+		require.Error(t, err)
+		assert.Equal(t, errInvalidNarrowInterface, errors.Cause(err))
+	})
+}
+
+func TestNewGenerator_overlappingMethods(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{.Import}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Buffered",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, g.methods, 3)
+	assert.Empty(t, g.methods["Flush"].FromEmbedded)
+	assert.Equal(t, []string{"Flusher"}, g.methods["Reset"].FromEmbedded)
+	assert.Empty(t, g.methods["Write"].FromEmbedded)
+	assert.Equal(t, "data []byte", g.methods["Write"].Params.String())
+
+	g, err = NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate:   "{{.Import}}",
+		SourcePackage:  "./testdata/source",
+		OutputFile:     "./out.go",
+		InterfaceName:  "Cache",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, g.methods, 1)
+	assert.Empty(t, g.methods["Get"].FromEmbedded)
+	assert.Equal(t, "key K", g.methods["Get"].Params.String())
+}
+
+func Test_mergeMethods_duplicateMethod(t *testing.T) {
+	methods := methodsList{"Flush": Method{Name: "Flush", Results: ParamsSlice{{Name: "err", Type: "error"}}}}
+	embedded := methodsList{"Flush": Method{Name: "Flush"}}
+
+	_, err := mergeMethods(methods, embedded)
+	require.Error(t, err)
+	assert.Equal(t, errDuplicateMethod, errors.Cause(err))
+
+	merged, err := mergeMethods(methods, methodsList{"Flush": Method{Name: "Flush", Results: ParamsSlice{{Name: "e1", Type: "error"}}}})
+	require.NoError(t, err)
+	assert.Equal(t, methods["Flush"], merged["Flush"])
+}
+
+func TestGenerator_Generate_unsafePointer(t *testing.T) {
+	g, err := NewGenerator(Options{
+		HeaderTemplate: "package generator\n",
+		BodyTemplate: `{{.Import}}
+			type decorator struct {
+				{{.Interface.Embedding.Type}}
+			}
+
+			{{range $method := .Interface.Methods}}
+			func (d decorator) {{$method.Declaration}} {
+				{{$method.Pass "d."}}
+			}
+			{{end}}`,
+		SourcePackage: "./testdata/source",
+		OutputFile:    "./out.go",
+		InterfaceName: "Memory",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"unsafe"}, methodImports(g.methods["Address"]))
+
+	buf := bytes.NewBuffer([]byte{})
+	require.NoError(t, g.Generate(buf))
+
+	assert.Contains(t, buf.String(), `import (
+	"unsafe"
+
+	"github.com/hexdigest/gowrap/generator/testdata/source"
+)`)
+	assert.Contains(t, buf.String(), "func (d decorator) Address(p unsafe.Pointer) (u1 uintptr) {")
+}
+
+func TestNewGenerator_buildFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		buildFlags  []string
+		wantMethods []string
+	}{
+		{
+			name:        "default build",
+			wantMethods: []string{"Default"},
+		},
+		{
+			name:        "special tag",
+			buildFlags:  []string{"-tags=special"},
+			wantMethods: []string{"Special"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   "{{.Import}}",
+				SourcePackage:  "./testdata/tagged",
+				OutputFile:     "./out.go",
+				InterfaceName:  "Service",
+				BuildFlags:     tt.buildFlags,
+			})
+			require.NoError(t, err)
+
+			var names []string
+			for _, m := range g.sortedMethods() {
+				names = append(names, m.Name)
+			}
+			assert.Equal(t, tt.wantMethods, names)
+		})
+	}
+}
+
+func TestNewGenerator_source(t *testing.T) {
+	source := []byte("package source\n\nimport \"io\"\n\n// Copier copies\ntype Copier interface {\n\tCopy(dst io.Writer, src io.Reader) (int64, error)\n}\n")
+
+	tests := []struct {
+		name          string
+		sourcePackage string
+		wantType      string
+		wantImport    bool
+	}{
+		{
+			name:          "source import path",
+			sourcePackage: "example.com/source",
+			wantType:      "source.Copier",
+			wantImport:    true,
+		},
+		{
+			name:     "destination package",
+			wantType: "Copier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(Options{
+				HeaderTemplate: "package generator\n",
+				BodyTemplate:   "{{.Import}}",
+				Source:         source,
+				SourcePackage:  tt.sourcePackage,
+				OutputFile:     "./out.go",
+				InterfaceName:  "Copier",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantType, g.interfaceType)
+			if tt.wantImport {
+				assert.Contains(t, g.Options.Imports, `"example.com/source"`)
+			} else {
+				assert.NotContains(t, g.Options.Imports, `"example.com/source"`)
+			}
+			assert.Contains(t, g.methods, "Copy")
+		})
+	}
+
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := NewGenerator(Options{
+			HeaderTemplate: "package generator\n",
+			BodyTemplate:   "{{.Import}}",
+			Source:         []byte("package source\n\ntype Copier interface {"),
+			OutputFile:     "./out.go",
+			InterfaceName:  "Copier",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse source: <stdin>:3:")
+	})
+}
@@ -77,26 +77,37 @@ func (g genericTypes) buildVars() (string, string) {
 	return buildGenericsWithBrackets(types), buildGenericsWithBrackets(params)
 }
 
-func buildGenericTypesFromSpec(ts *ast.TypeSpec) (types genericTypes) {
-	if ts.TypeParams != nil {
-		for _, param := range ts.TypeParams.List {
-			if param != nil {
-				if gpt, ok := param.Type.(*ast.Ident); ok {
-					var paramNames []string
-					for _, name := range param.Names {
-						if name != nil {
-							paramNames = append(paramNames, name.Name)
-						}
-					}
-					types = append(types, genericType{
-						Type:  gpt.Name,
-						Names: paramNames,
-					})
-				}
+// buildGenericTypesFromSpec returns the type params of the type spec grouped by their constraints,
+// constraints are printed with the printer so the types declared in the source package are qualified
+func buildGenericTypesFromSpec(ts *ast.TypeSpec, printer typePrinter) (types genericTypes, err error) {
+	if ts.TypeParams == nil {
+		return nil, nil
+	}
+
+	for _, param := range ts.TypeParams.List {
+		if param == nil {
+			continue
+		}
+
+		constraint, err := printer.PrintType(param.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		var paramNames []string
+		for _, name := range param.Names {
+			if name != nil {
+				paramNames = append(paramNames, name.Name)
 			}
 		}
+
+		types = append(types, genericType{
+			Type:  constraint,
+			Names: paramNames,
+		})
 	}
-	return
+
+	return types, nil
 }
 
 func buildGenericParamsString(typeStr string, genericTypes genericTypes, genericParams genericParams) string {
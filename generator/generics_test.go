@@ -2,8 +2,11 @@ package generator
 
 import (
 	"go/ast"
+	"go/token"
 	"reflect"
 	"testing"
+
+	"github.com/hexdigest/gowrap/printer"
 )
 
 func Test_genericParam_String(t *testing.T) {
@@ -116,10 +119,58 @@ func Test_buildGenericTypesFromSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "build generic types with selector and union constraints from spec",
+			args: args{
+				ts: &ast.TypeSpec{
+					TypeParams: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.SelectorExpr{
+									X:   &ast.Ident{Name: "fmt"},
+									Sel: &ast.Ident{Name: "Stringer"},
+								},
+								Names: []*ast.Ident{{Name: "K"}},
+							},
+							{
+								Type: &ast.InterfaceType{
+									Methods: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.BinaryExpr{
+													X:  &ast.UnaryExpr{Op: token.TILDE, X: &ast.Ident{Name: "int"}},
+													Op: token.OR,
+													Y:  &ast.UnaryExpr{Op: token.TILDE, X: &ast.Ident{Name: "string"}},
+												},
+											},
+										},
+									},
+								},
+								Names: []*ast.Ident{{Name: "V"}},
+							},
+						},
+					},
+				},
+			},
+			wantTypes: genericTypes{
+				{
+					Type:  "fmt.Stringer",
+					Names: []string{"K"},
+				},
+				{
+					Type:  "interface{\n~int | ~string\n}",
+					Names: []string{"V"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if gotTypes := buildGenericTypesFromSpec(tt.args.ts); !reflect.DeepEqual(gotTypes, tt.wantTypes) {
+			gotTypes, err := buildGenericTypesFromSpec(tt.args.ts, printer.New(token.NewFileSet(), nil, "source"))
+			if err != nil {
+				t.Fatalf("buildGenericTypesFromSpec() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotTypes, tt.wantTypes) {
 				t.Errorf("buildGenericTypesFromSpec() = %v, want %v", gotTypes, tt.wantTypes)
 			}
 		})
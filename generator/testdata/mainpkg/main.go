@@ -0,0 +1,8 @@
+package main
+
+// Service is an interface declared in the main package
+type Service interface {
+	Do(s string) error
+}
+
+func main() {}
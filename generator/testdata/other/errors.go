@@ -0,0 +1,4 @@
+package other
+
+// Error is an alias of the error interface declared in another package
+type Error = error
@@ -0,0 +1,13 @@
+package other
+
+import (
+	enc "encoding/json"
+)
+
+// Setter is embedded into the interface declared in another package
+type Setter interface {
+	SetRaw(m map[string]map[enc.Number][]enc.RawMessage)
+}
+
+// Key is used as a map key by the interfaces declared in another package
+type Key string
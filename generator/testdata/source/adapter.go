@@ -0,0 +1,22 @@
+package source
+
+// ReadWriter is adapted to the narrower interfaces
+type ReadWriter interface {
+	Read(p []byte) (n int, err error)
+	Write(p []byte) (n int, err error)
+}
+
+// Reader is a subset of ReadWriter
+type Reader interface {
+	Read(buf []byte) (int, error)
+}
+
+// BrokenReader has a Read method incompatible with ReadWriter
+type BrokenReader interface {
+	Read(p []byte) error
+}
+
+// Seeker has a method missing in ReadWriter
+type Seeker interface {
+	Seek(offset int64, whence int) (int64, error)
+}
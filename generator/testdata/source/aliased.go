@@ -0,0 +1,10 @@
+package source
+
+import (
+	enc "encoding/json"
+)
+
+// Aliased returns a type of the package imported with an alias
+type Aliased interface {
+	Raw() enc.RawMessage
+}
@@ -0,0 +1,11 @@
+package source
+
+// BufSize is a size of the buffer accepted by Arrays.WriteBuf
+const BufSize = 32
+
+// Arrays has methods that accept arrays
+type Arrays interface {
+	Write(buf [16]byte)
+	WriteBuf(buf [BufSize]byte)
+	WriteHeader(buf [BufSize*2 + 1]byte)
+}
@@ -0,0 +1,12 @@
+package source
+
+import (
+	_ "io"
+	"io"
+)
+
+// Blank refers to the package that is blank imported as well
+type Blank interface {
+	io.Closer
+	Copy(r io.Reader) error
+}
@@ -0,0 +1,23 @@
+package source
+
+import "net/url"
+
+// Key is a comparable struct
+type Key struct {
+	ID   int
+	Name string
+}
+
+// Keys is a slice of keys
+type Keys []Key
+
+// Filter contains a slice so it can't be compared
+type Filter struct {
+	IDs []int
+}
+
+// Finder has params of the comparable and not comparable types
+type Finder interface {
+	Find(key Key, filter *Filter, ch chan int, names [2]string, u *url.URL) (Key, error)
+	FindAll(keys Keys, filter Filter, query url.Values, fn func(), m map[string]int, ids ...int) error
+}
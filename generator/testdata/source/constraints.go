@@ -0,0 +1,13 @@
+package source
+
+// Number is a constraint-only interface
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Constrained embeds constraint-only interfaces along with the methods
+type Constrained interface {
+	comparable
+	Number
+	Measure() int
+}
@@ -0,0 +1,21 @@
+package source
+
+// List is a generic type used as a type argument of the embedded interface
+type List[T any] []T
+
+// Container is a generic interface embedded with the generic type argument
+type Container[E any] interface {
+	Put(e E) error
+	Get() (E, error)
+}
+
+// ListContainer embeds Container instantiated with the generic List of its own type param
+type ListContainer[T any] interface {
+	Container[List[T]]
+	Len() int
+}
+
+// IntListContainer embeds ListContainer instantiated with a concrete type
+type IntListContainer interface {
+	ListContainer[int]
+}
@@ -0,0 +1,42 @@
+package source
+
+// Directed has the methods annotated with the gowrap directives
+type Directed interface {
+	// Get returns the value.
+	//
+	// gowrap:skip
+	Get(key string) (string, error)
+
+	//gowrap:template=passthrough
+	//gowrap:timeout = 5s
+	Set(key, value string) error
+
+	// Len isn't annotated
+	Len() int
+}
+
+// Misdirected has the method annotated with the malformed directive
+type Misdirected interface {
+	// gowrap:skip it
+	Get(key string) (string, error)
+}
+
+// Skipped has the methods marked with the skip directive
+type Skipped interface {
+	//gowrap:skip
+	Save(key string) error
+
+	//gowrap:skip=false
+	Delete(key string) error
+
+	//gowrap:skip
+	Ping() error
+
+	Ready() error
+}
+
+// SkippedFunc has the only method marked with the skip directive
+type SkippedFunc interface {
+	//gowrap:skip
+	Do(key string) error
+}
@@ -0,0 +1,21 @@
+package source
+
+import "io"
+
+// Inner is embedded into Middle
+type Inner interface {
+	Read(p []byte) (int, error)
+}
+
+// Middle is embedded into Outer
+type Middle interface {
+	Inner
+	io.Closer
+	Flush() error
+}
+
+// Outer embeds Inner through Middle
+type Outer interface {
+	Middle
+	Name() string
+}
@@ -0,0 +1,20 @@
+package source
+
+import "github.com/hexdigest/gowrap/generator/testdata/other"
+
+// Failure is an alias of the error interface
+type Failure = error
+
+// Reason is an alias of the Failure alias
+type Reason = Failure
+
+// Problem is an alias of the error alias declared in another package
+type Problem = other.Error
+
+// Validator returns errors of the aliased types
+type Validator interface {
+	Validate(value string) Failure
+	Check(value string) (bool, Reason)
+	Verify(value string) (ok bool, failure other.Error)
+	Inspect(value string) Problem
+}
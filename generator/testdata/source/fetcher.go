@@ -0,0 +1,12 @@
+package source
+
+import (
+	"context"
+	"net/url"
+)
+
+// Fetcher references several packages in the methods signatures
+type Fetcher interface {
+	Fetch(ctx context.Context, u *url.URL) ([]byte, error)
+	Len() int
+}
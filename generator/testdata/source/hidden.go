@@ -0,0 +1,8 @@
+package source
+
+import "github.com/hexdigest/gowrap/generator/testdata/source/internal/hidden"
+
+// WithHidden embeds an interface declared in the internal package
+type WithHidden interface {
+	hidden.Hidden
+}
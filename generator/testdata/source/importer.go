@@ -0,0 +1,7 @@
+package source
+
+// Importer has the methods named the same as the TemplateInputs.Import helper and the template funcs
+type Importer interface {
+	Import(path string) error
+	Snake(s string) string
+}
@@ -0,0 +1,15 @@
+package source
+
+import "io"
+
+// Opener accepts the interface literals embedding other interfaces
+type Opener interface {
+	Do(r interface {
+		io.Reader
+		Name() string
+	}) error
+	Wrap(n interface {
+		Named
+		Close() error
+	}) interface{ io.Closer }
+}
@@ -0,0 +1,9 @@
+package hidden
+
+// Hidden is an interface that can only be imported by the source package
+type Hidden interface {
+	Get() Value
+}
+
+// Value is returned by Hidden.Get
+type Value struct{}
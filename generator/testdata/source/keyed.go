@@ -0,0 +1,25 @@
+package source
+
+import "fmt"
+
+// Number is a constraint for the numeric values
+type Number interface {
+	~int | ~float64
+}
+
+// Keyed is a generic interface embedded into KeyedLayer
+type Keyed[K comparable, V any] interface {
+	Get(k K) (V, error)
+}
+
+// KeyedLayer embeds Keyed and narrows its value type
+type KeyedLayer[K comparable, V Number] interface {
+	Keyed[K, V]
+	Sum(keys ...K) V
+}
+
+// KeyedMeta embeds KeyedLayer and adds its own type parameter
+type KeyedMeta[K comparable, V Number, M fmt.Stringer] interface {
+	KeyedLayer[K, V]
+	Meta(k K) M
+}
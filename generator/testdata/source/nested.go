@@ -0,0 +1,28 @@
+package source
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/hexdigest/gowrap/generator/testdata/other"
+)
+
+// Key is used as a map key in Nested
+type Key string
+
+// Nested accepts nested maps and slices of the types declared in other packages
+type Nested interface {
+	Set(m map[string]map[time.Month][]*url.URL)
+	SetLocal(m map[Key]map[time.Weekday]chan []Key)
+}
+
+// NestedEmbedded embeds the interface which methods use the packages imported with an alias
+type NestedEmbedded interface {
+	other.Setter
+}
+
+// AnyMap uses the predeclared any as a value of the maps keyed by the type declared in another package
+type AnyMap interface {
+	Cache(key other.Key) map[other.Key]any
+	Fill(m map[other.Key]any) error
+}
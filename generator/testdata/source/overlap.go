@@ -0,0 +1,28 @@
+package source
+
+import "io"
+
+// Flusher declares the Flush method that is also declared by Buffered
+type Flusher interface {
+	Flush() error
+	Reset()
+}
+
+// Buffered declares methods with the same signatures as the embedded ones
+type Buffered interface {
+	Flush() error
+	Flusher
+	io.Writer
+	Write(data []byte) (int, error)
+}
+
+// Getter is a generic interface embedded into Cache
+type Getter[K comparable, V any] interface {
+	Get(k K) (V, error)
+}
+
+// Cache declares the Get method of the embedded Getter
+type Cache[K comparable, V any] interface {
+	Getter[K, V]
+	Get(key K) (V, error)
+}
@@ -0,0 +1,7 @@
+package source
+
+// Decoder has params and results named as the receivers of the decorator methods
+type Decoder interface {
+	Decode(_d []byte) (d int, err error)
+	Split(d []byte) (_d []byte, rest []byte)
+}
@@ -0,0 +1,26 @@
+package source
+
+// Iface is used to test decorators generated for the interfaces declared in another package
+type Iface interface {
+	Sub() OtherIface
+}
+
+// OtherIface is returned by Iface.Sub
+type OtherIface interface {
+	Name() string
+}
+
+// Point has unnamed results of the same type
+type Point interface {
+	Coords() (int, int)
+}
+
+// Tree returns collections of itself
+type Tree interface {
+	Parent() Tree
+	Children() []Tree
+	Path() [2]Tree
+	ByName() map[string]Tree
+	Add(children ...Tree)
+	Names() []string
+}
@@ -0,0 +1,6 @@
+package source
+
+// Store is a generic interface
+type Store[K comparable, V any] interface {
+	Get(k K) (V, error)
+}
@@ -0,0 +1,7 @@
+package source
+
+// Stream is a generic interface which methods return the interface itself
+type Stream[T any] interface {
+	Map(f func(T) T) Stream[T]
+	First() (T, bool)
+}
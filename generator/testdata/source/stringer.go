@@ -0,0 +1,9 @@
+package source
+
+import "fmt"
+
+// Named embeds fmt.Stringer
+type Named interface {
+	fmt.Stringer
+	Rename(name string) error
+}
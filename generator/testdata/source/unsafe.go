@@ -0,0 +1,8 @@
+package source
+
+import "unsafe"
+
+// Memory uses unsafe.Pointer and uintptr in the method signature
+type Memory interface {
+	Address(p unsafe.Pointer) uintptr
+}
@@ -0,0 +1,8 @@
+package source
+
+// Watcher returns the channels of its own instances
+type Watcher interface {
+	Watch() (<-chan Watcher, error)
+	Subscribe(ch chan<- Watcher)
+	Events() <-chan string
+}
@@ -0,0 +1,9 @@
+//go:build special
+// +build special
+
+package tagged
+
+// Service is declared differently when the special build tag is set
+type Service interface {
+	Special() error
+}
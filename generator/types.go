@@ -3,23 +3,138 @@ package generator
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"strconv"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 type typePrinter interface {
 	PrintType(ast.Node) (string, error)
 }
 
+// ReceiverName is a name of the receiver of the decorator methods in the bundled templates,
+// params and results of the methods named the same way are renamed to avoid the collisions
+const ReceiverName = "_d"
+
 // Method represents a method's signature
 type Method struct {
 	Doc     []string
 	Comment []string
 	Name    string
+
+	// DocText is the text of the method's doc comment without the comment markers
+	DocText string
 	Params  ParamsSlice
 	Results ParamsSlice
 
 	ReturnsError   bool
 	AcceptsContext bool
+
+	// ContextParamName is a name of the context.Context param when AcceptsContext is true,
+	// the param is always named ctx even if it's unnamed or has another name in the source
+	ContextParamName string
+
+	// FromEmbedded is a chain of the embedded interfaces names the method is promoted through,
+	// starting from the interface embedded into the source one. It's empty for the methods
+	// declared in the source interface directly
+	FromEmbedded []string
+
+	// Directives are parsed from the "//gowrap:name=value" lines of the method's doc comment, see parseDirective.
+	// The lines are removed from the Doc and the DocText, templates can check them like {{$method.Directives.skip}}
+	Directives map[string]string
+
+	// Primary is true if the method is promoted from the interface set with Options.PrimaryEmbedded
+	Primary bool
+
+	//selectors are names of the packages referenced by the method's params and results,
+	//imports are their import paths resolved using the imports of the file declaring the method
+	selectors []string
+	imports   []string
+}
+
+// directivePrefix starts the doc comment lines parsed as the gowrap directives:
+//
+//	directive = "//" [ " " ] "gowrap:" name [ "=" value ]
+//
+// The name is a Go identifier and the value is the rest of the line, the directive without
+// the value is "true". The values of the boolean directives like "skip" must be accepted by
+// strconv.ParseBool, i.e. "//gowrap:skip=false" doesn't skip the method
+const directivePrefix = "gowrap:"
+
+// booleanDirectives are the directives whose values are checked by strconv.ParseBool
+var booleanDirectives = map[string]bool{
+	"skip": true,
+}
+
+var errMalformedDirective = errors.New("malformed gowrap directive")
+var errNotBooleanDirective = errors.New("gowrap directive value is not a boolean")
+
+// parseDirective parses the comment line of the form "//gowrap:name" or "//gowrap:name=value",
+// a single space after the slashes is allowed, i.e. "// gowrap:skip". The name must be an identifier
+// so templates can refer to it, the value is the rest of the line with the surrounding spaces trimmed,
+// it's "true" if it's omitted. The ok is false if the line is not a directive
+func parseDirective(line string) (name, value string, ok bool, err error) {
+	text := strings.TrimPrefix(line, "//")
+	if text == line {
+		return "", "", false, nil
+	}
+
+	text = strings.TrimPrefix(text, " ")
+	if !strings.HasPrefix(text, directivePrefix) {
+		return "", "", false, nil
+	}
+
+	name, value = strings.TrimPrefix(text, directivePrefix), "true"
+	if i := strings.Index(name, "="); i >= 0 {
+		name, value = name[:i], strings.TrimSpace(name[i+1:])
+	}
+
+	if name = strings.TrimSpace(name); !token.IsIdentifier(name) {
+		return "", "", false, errors.Wrap(errMalformedDirective, line)
+	}
+
+	return name, value, true, nil
+}
+
+// parseDirectives returns the doc comment without the directive lines along with the parsed directives,
+// the empty lines left at the end of the comment after the directives are removed are dropped as well
+func parseDirectives(doc *ast.CommentGroup) (*ast.CommentGroup, map[string]string, error) {
+	result := &ast.CommentGroup{}
+	if doc == nil {
+		return result, nil, nil
+	}
+
+	var directives map[string]string
+	for _, comment := range doc.List {
+		name, value, ok, err := parseDirective(comment.Text)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !ok {
+			result.List = append(result.List, comment)
+			continue
+		}
+
+		if booleanDirectives[name] {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, nil, errors.Wrap(errNotBooleanDirective, comment.Text)
+			}
+		}
+
+		if directives == nil {
+			directives = make(map[string]string)
+		}
+		directives[name] = value
+	}
+
+	for len(result.List) > 0 && strings.TrimSpace(result.List[len(result.List)-1].Text) == "//" {
+		result.List = result.List[:len(result.List)-1]
+	}
+
+	return result, directives, nil
 }
 
 // Param represents fuction argument or result
@@ -29,6 +144,25 @@ type Param struct {
 	Name     string
 	Type     string
 	Variadic bool
+
+	// Self is true when the type of the param is the decorated interface itself,
+	// i.e. the method returns another instance of the interface that can be decorated as well
+	Self bool
+
+	// SelfElem is true when the param is a slice, an array or a map of the decorated interface values,
+	// so every element can be decorated
+	SelfElem bool
+
+	// SelfChan is true when the param is a channel of the decorated interface values,
+	// so the values can be decorated while they're forwarded to another channel
+	SelfChan bool
+
+	// Comparable is true when the values of the param's type can be compared with ==, i.e. used as map keys.
+	// Named types are resolved in the source and the imported packages, type params are assumed comparable
+	Comparable bool
+
+	//declared is true when the param is named in the source, otherwise the name is generated
+	declared bool
 }
 
 // ParamsSlice slice of parameters
@@ -65,21 +199,56 @@ func (p Param) Pass() string {
 	return p.Name
 }
 
-// NewMethod returns pointer to Signature struct or error
-func NewMethod(name string, fi *ast.Field, printer typePrinter, genericTypes genericTypes, genericParams genericParams) (*Method, error) {
+// isContextType reports whether the expression is the Context type
+// of the "context" package imported with the given import specs
+func isContextType(expr ast.Expr, imports []*ast.ImportSpec) bool {
+	se, ok := expr.(*ast.SelectorExpr)
+	if !ok || se.Sel.Name != "Context" {
+		return false
+	}
+
+	pkg, ok := se.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	for _, i := range imports {
+		if unquote(i.Path.Value) != "context" {
+			continue
+		}
+
+		if i.Name == nil && pkg.Name == "context" || i.Name != nil && i.Name.Name == pkg.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewMethod returns pointer to Signature struct or error,
+// imports of the file declaring the method are used to detect the context parameter
+func NewMethod(name string, fi *ast.Field, printer typePrinter, imports []*ast.ImportSpec, genericTypes genericTypes, genericParams genericParams) (*Method, error) {
 	f, ok := fi.Type.(*ast.FuncType)
 	if !ok {
 		return nil, fmt.Errorf("%q is not a method", name)
 	}
 
 	m := Method{Name: name}
-	if fi.Doc != nil && len(fi.Doc.List) > 0 {
-		m.Doc = make([]string, 0, len(fi.Doc.List))
-		for _, comment := range fi.Doc.List {
+	doc, directives, err := parseDirectives(fi.Doc)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+
+	if len(doc.List) > 0 {
+		m.Doc = make([]string, 0, len(doc.List))
+		for _, comment := range doc.List {
 			m.Doc = append(m.Doc, comment.Text)
 		}
 	}
 
+	m.DocText = doc.Text()
+	m.Directives = directives
+
 	if fi.Comment != nil && len(fi.Comment.List) > 0 {
 		m.Comment = make([]string, 0, len(fi.Comment.List))
 		for _, comment := range fi.Comment.List {
@@ -87,7 +256,8 @@ func NewMethod(name string, fi *ast.Field, printer typePrinter, genericTypes gen
 		}
 	}
 
-	usedNames := map[string]bool{}
+	//params and results can't be named as the receiver of the decorator methods
+	usedNames := map[string]bool{ReceiverName: true}
 
 	//Always name the last return parameter as an "err" if it's of type "error"
 	if f.Results != nil {
@@ -97,14 +267,12 @@ func NewMethod(name string, fi *ast.Field, printer typePrinter, genericTypes gen
 	}
 
 	if len(f.Params.List) > 0 {
-		if se, ok := f.Params.List[0].Type.(*ast.SelectorExpr); ok {
-			m.AcceptsContext = ok && se.Sel.Name == "Context"
+		if isContextType(f.Params.List[0].Type, imports) {
+			m.AcceptsContext = true
 			usedNames["ctx"] = true
 		}
 	}
 
-	var err error
-
 	m.Params, err = makeParams(f.Params, usedNames, printer, genericTypes, genericParams)
 	if err != nil {
 		return nil, err
@@ -120,16 +288,39 @@ func NewMethod(name string, fi *ast.Field, printer typePrinter, genericTypes gen
 
 	if m.AcceptsContext {
 		m.Params[0].Name = "ctx"
+		m.ContextParamName = m.Params[0].Name
 	}
 
+	m.selectors = packageSelectors(f)
+
 	return &m, nil
 }
 
+// packageSelectors returns the names of the packages used to qualify types in the function signature
+func packageSelectors(f *ast.FuncType) []string {
+	var names []string
+	seen := map[string]bool{}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if se, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := se.X.(*ast.Ident); ok && !seen[ident.Name] {
+				seen[ident.Name] = true
+				names = append(names, ident.Name)
+			}
+		}
+		return true
+	})
+
+	return names
+}
+
 // NewParam returns Param struct
 func NewParam(name string, fi *ast.Field, usedNames map[string]bool, printer typePrinter, genericTypes genericTypes, genericParams genericParams) (*Param, error) {
 	typ := fi.Type
-	if name == "" || usedNames[name] {
-		name = genName(typePrefix(typ), 1, usedNames)
+	declared := true
+	//blank identifier can't be passed to the decorated method
+	if name == "" || name == "_" || usedNames[name] {
+		name, declared = genName(typePrefix(typ), 1, usedNames), false
 	}
 
 	usedNames[name] = true
@@ -146,6 +337,7 @@ func NewParam(name string, fi *ast.Field, usedNames map[string]bool, printer typ
 		Name:     name,
 		Variadic: variadic,
 		Type:     typeStr,
+		declared: declared,
 	}
 	if fi.Doc != nil && len(fi.Doc.List) > 0 {
 		p.Doc = make([]string, 0, len(fi.Doc.List))
@@ -211,6 +403,10 @@ func typePrefix(e ast.Expr) string {
 		return "st"
 	case *ast.FuncType:
 		return "f"
+	case *ast.IndexExpr:
+		return typePrefix(t.X) //Stream[T] -> s
+	case *ast.IndexListExpr:
+		return typePrefix(t.X)
 	case *ast.Ident:
 		return strings.ToLower(t.Name[0:1])
 	}
@@ -227,6 +423,13 @@ func genName(prefix string, n int, usedNames map[string]bool) string {
 	return name
 }
 
+// Undecorated returns true if the method should be passed to the base implementation without decoration,
+// i.e. it's marked with the "//gowrap:skip" directive or promoted from the primary embedded interface
+func (m Method) Undecorated() bool {
+	skip, _ := strconv.ParseBool(m.Directives["skip"])
+	return m.Primary || skip
+}
+
 // Call returns a string with the method call
 func (m Method) Call() string {
 	params := []string{}
@@ -265,6 +468,19 @@ func (m Method) ResultsNames() string {
 	return strings.Join(ss, ", ")
 }
 
+// DocComment returns the method's doc comment as it's written in the source interface,
+// so templates can put it above the decorated method
+func (m Method) DocComment() string {
+	return strings.Join(m.Doc, "\n")
+}
+
+// Defer returns a deferred call of the fn with the method results passed as arguments.
+// Results are always named in the method declaration, so fn receives the values
+// that are actually returned by the method
+func (m Method) Defer(fn string) string {
+	return "defer func() {\n" + fn + "(" + m.ResultsNames() + ")\n}()"
+}
+
 // ParamsStruct returns a struct type with fields corresponding
 // to the method params
 func (m Method) ParamsStruct() string {
@@ -319,6 +535,31 @@ func (m Method) HasResults() bool {
 	return len(m.Results) > 0
 }
 
+// IsClose returns true if the method has the signature of the io.Closer's Close method
+func (m Method) IsClose() bool {
+	return m.Name == "Close" && len(m.Params) == 0 && len(m.Results) == 1 && m.ReturnsError
+}
+
+// healthCheckNames are the names of the methods following the health check conventions
+var healthCheckNames = map[string]bool{"Ping": true, "Health": true, "HealthCheck": true, "Ready": true, "Live": true}
+
+// IsHealthCheck returns true if the method follows the health check conventions: it's named Ping, Health,
+// HealthCheck, Ready or Live, accepts nothing but an optional context and returns only an error
+func (m Method) IsHealthCheck() bool {
+	params := len(m.Params)
+	if m.AcceptsContext {
+		params--
+	}
+
+	return healthCheckNames[m.Name] && params == 0 && len(m.Results) == 1 && m.ReturnsError
+}
+
+// IsStringer returns true if the method has the signature of the fmt.Stringer's String method.
+// Templates can use it to delegate the String method to the base implementation without decoration
+func (m Method) IsStringer() bool {
+	return m.Name == "String" && len(m.Params) == 0 && len(m.Results) == 1 && m.Results[0].Type == "string"
+}
+
 // ReturnStruct returns return statement with the return params
 // taken from the structName
 func (m Method) ReturnStruct(structName string) string {
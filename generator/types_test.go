@@ -1,9 +1,16 @@
 package generator
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hexdigest/gowrap/printer"
 )
 
 func TestMethod_Declaration(t *testing.T) {
@@ -71,6 +78,186 @@ func TestMethod_HasParams(t *testing.T) {
 	})
 }
 
+func TestMethod_IsClose(t *testing.T) {
+	t.Run("close", func(t *testing.T) {
+		m := Method{
+			Name:         "Close",
+			Results:      []Param{{Name: "err", Type: "error"}},
+			ReturnsError: true,
+		}
+		assert.True(t, m.IsClose())
+	})
+
+	t.Run("close with params", func(t *testing.T) {
+		m := Method{
+			Name:         "Close",
+			Params:       []Param{{Name: "force", Type: "bool"}},
+			Results:      []Param{{Name: "err", Type: "error"}},
+			ReturnsError: true,
+		}
+		assert.False(t, m.IsClose())
+	})
+
+	t.Run("close without error", func(t *testing.T) {
+		m := Method{
+			Name: "Close",
+		}
+		assert.False(t, m.IsClose())
+	})
+}
+
+func Test_parseDirective(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantName  string
+		wantValue string
+		wantOK    bool
+		wantErr   error
+	}{
+		{line: "//gowrap:skip", wantName: "skip", wantValue: "true", wantOK: true},
+		{line: "// gowrap:skip", wantName: "skip", wantValue: "true", wantOK: true},
+		{line: "//gowrap:template=passthrough", wantName: "template", wantValue: "passthrough", wantOK: true},
+		{line: "//gowrap:timeout = 5s ", wantName: "timeout", wantValue: "5s", wantOK: true},
+		{line: "//gowrap:empty=", wantName: "empty", wantValue: "", wantOK: true},
+		{line: "// Get returns the value"},
+		{line: "//  gowrap:skip"},
+		{line: "/* gowrap:skip */"},
+		{line: "//gowrap:", wantErr: errMalformedDirective},
+		{line: "// gowrap:skip it", wantErr: errMalformedDirective},
+		{line: "//gowrap:time-out=5s", wantErr: errMalformedDirective},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			name, value, ok, err := parseDirective(tt.line)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, errors.Cause(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func Test_parseDirectives(t *testing.T) {
+	doc := func(lines ...string) *ast.CommentGroup {
+		group := &ast.CommentGroup{}
+		for _, line := range lines {
+			group.List = append(group.List, &ast.Comment{Text: line})
+		}
+		return group
+	}
+
+	t.Run("boolean directives", func(t *testing.T) {
+		result, directives, err := parseDirectives(doc("// Get returns the value.", "//gowrap:skip=false", "//gowrap:timeout=5s"))
+		require.NoError(t, err)
+		assert.Equal(t, doc("// Get returns the value."), result)
+		assert.Equal(t, map[string]string{"skip": "false", "timeout": "5s"}, directives)
+	})
+
+	t.Run("not a boolean value", func(t *testing.T) {
+		_, _, err := parseDirectives(doc("//gowrap:skip=yes"))
+		assert.Equal(t, errNotBooleanDirective, errors.Cause(err))
+		assert.Contains(t, err.Error(), "//gowrap:skip=yes")
+	})
+}
+
+func TestMethod_IsHealthCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   bool
+	}{
+		{
+			name: "ping",
+			method: Method{
+				Name:         "Ping",
+				Results:      []Param{{Name: "err", Type: "error"}},
+				ReturnsError: true,
+			},
+			want: true,
+		},
+		{
+			name: "ready with context",
+			method: Method{
+				Name:           "Ready",
+				Params:         []Param{{Name: "ctx", Type: "context.Context"}},
+				Results:        []Param{{Name: "err", Type: "error"}},
+				AcceptsContext: true,
+				ReturnsError:   true,
+			},
+			want: true,
+		},
+		{
+			name: "ping with params",
+			method: Method{
+				Name:         "Ping",
+				Params:       []Param{{Name: "host", Type: "string"}},
+				Results:      []Param{{Name: "err", Type: "error"}},
+				ReturnsError: true,
+			},
+		},
+		{
+			name: "ping with result",
+			method: Method{
+				Name:         "Ping",
+				Results:      []Param{{Name: "d1", Type: "time.Duration"}, {Name: "err", Type: "error"}},
+				ReturnsError: true,
+			},
+		},
+		{
+			name:   "ping without error",
+			method: Method{Name: "Ping"},
+		},
+		{
+			name: "unconventional name",
+			method: Method{
+				Name:         "Check",
+				Results:      []Param{{Name: "err", Type: "error"}},
+				ReturnsError: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.method.IsHealthCheck())
+		})
+	}
+}
+
+func TestMethod_IsStringer(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		m := Method{
+			Name:    "String",
+			Results: []Param{{Name: "s1", Type: "string"}},
+		}
+		assert.True(t, m.IsStringer())
+	})
+
+	t.Run("string with params", func(t *testing.T) {
+		m := Method{
+			Name:    "String",
+			Params:  []Param{{Name: "indent", Type: "int"}},
+			Results: []Param{{Name: "s1", Type: "string"}},
+		}
+		assert.False(t, m.IsStringer())
+	})
+
+	t.Run("string with error", func(t *testing.T) {
+		m := Method{
+			Name:         "String",
+			Results:      []Param{{Name: "s1", Type: "string"}, {Name: "err", Type: "error"}},
+			ReturnsError: true,
+		}
+		assert.False(t, m.IsStringer())
+	})
+}
+
 func TestMethod_ParamsStruct(t *testing.T) {
 	m := Method{
 		Name:   "method",
@@ -95,6 +282,14 @@ func TestMethod_ResultsNames(t *testing.T) {
 	assert.Equal(t, "s, t", m.ResultsNames())
 }
 
+func TestMethod_Defer(t *testing.T) {
+	m := Method{
+		Name:    "method",
+		Results: []Param{{Name: "s"}, {Name: "err"}},
+	}
+	assert.Equal(t, "defer func() {\nd.after(s, err)\n}()", m.Defer("d.after"))
+}
+
 func TestMethod_Pass(t *testing.T) {
 	t.Run("no results", func(t *testing.T) {
 		m := Method{
@@ -137,3 +332,143 @@ func TestMethod_ResultsMap(t *testing.T) {
 	}
 	assert.Equal(t, "map[string]interface{}{\n\"s\": s}", m.ResultsMap())
 }
+
+func TestNewMethod_builtinNames(t *testing.T) {
+	fs := token.NewFileSet()
+	expr, err := parser.ParseExprFrom(fs, "", "interface{ M(len int, error string, cap, _ int) (n int, err error) }", 0)
+	require.NoError(t, err)
+
+	field := expr.(*ast.InterfaceType).Methods.List[0]
+
+	m, err := NewMethod("M", field, printer.New(fs, nil, ""), nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.True(t, m.ReturnsError)
+	assert.Equal(t, "len int, error string, cap int, i1 int", m.Params.String())
+	assert.Equal(t, "M(len, error, cap, i1)", m.Call())
+	assert.Equal(t, "n, err", m.ResultsNames())
+}
+
+func TestNewMethod_context(t *testing.T) {
+	tests := []struct {
+		name     string
+		imports  string
+		method   string
+		want     bool
+		wantName string
+		wantCall string
+	}{
+		{
+			name:     "named context",
+			imports:  `"context"`,
+			method:   "M(c context.Context, s string)",
+			want:     true,
+			wantName: "ctx",
+			wantCall: "M(ctx, s)",
+		},
+		{
+			name:     "unnamed context",
+			imports:  `"context"`,
+			method:   "M(context.Context, string)",
+			want:     true,
+			wantName: "ctx",
+			wantCall: "M(ctx, s1)",
+		},
+		{
+			name:     "aliased context package",
+			imports:  `stdctx "context"`,
+			method:   "M(_ stdctx.Context)",
+			want:     true,
+			wantName: "ctx",
+			wantCall: "M(ctx)",
+		},
+		{
+			name:     "context is not the first param",
+			imports:  `"context"`,
+			method:   "M(s string, ctx context.Context)",
+			wantCall: "M(s, ctx)",
+		},
+		{
+			name:     "context type of another package",
+			imports:  `"github.com/example/app"`,
+			method:   "M(c app.Context)",
+			wantCall: "M(c)",
+		},
+		{
+			name:     "another package named context",
+			imports:  `context "github.com/example/context"`,
+			method:   "M(c context.Context)",
+			wantCall: "M(c)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := token.NewFileSet()
+			src := "package p\nimport " + tt.imports + "\ntype I interface{ " + tt.method + " }"
+			f, err := parser.ParseFile(fs, "", src, 0)
+			require.NoError(t, err)
+
+			it := f.Decls[1].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).Type.(*ast.InterfaceType)
+			field := it.Methods.List[0]
+
+			m, err := NewMethod("M", field, printer.New(fs, nil, ""), f.Imports, nil, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, m.AcceptsContext)
+			assert.Equal(t, tt.wantName, m.ContextParamName)
+			assert.Equal(t, tt.wantCall, m.Call())
+		})
+	}
+}
+
+func TestNewMethod_doc(t *testing.T) {
+	fs := token.NewFileSet()
+	expr, err := parser.ParseExprFrom(fs, "", `interface{
+		// M does something.
+		//
+		// Deprecated: use N instead.
+		M()
+
+		/* N does something else */
+		N()
+
+		O()
+	}`, parser.ParseComments)
+	require.NoError(t, err)
+
+	methods := expr.(*ast.InterfaceType).Methods.List
+
+	m, err := NewMethod("M", methods[0], printer.New(fs, nil, ""), nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "M does something.\n\nDeprecated: use N instead.\n", m.DocText)
+	assert.Equal(t, "// M does something.\n//\n// Deprecated: use N instead.", m.DocComment())
+
+	n, err := NewMethod("N", methods[1], printer.New(fs, nil, ""), nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/* N does something else */", n.DocComment())
+
+	o, err := NewMethod("O", methods[2], printer.New(fs, nil, ""), nil, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, o.DocText)
+	assert.Empty(t, o.DocComment())
+}
+
+func TestMethod_Undecorated(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		want   bool
+	}{
+		{name: "decorated", method: Method{Directives: map[string]string{"timeout": "1s"}}},
+		{name: "skip directive", method: Method{Directives: map[string]string{"skip": "true"}}, want: true},
+		{name: "skip directive is false", method: Method{Directives: map[string]string{"skip": "false"}}},
+		{name: "primary", method: Method{Primary: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.method.Undecorated())
+		})
+	}
+}
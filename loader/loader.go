@@ -1,6 +1,9 @@
 package loader
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -18,6 +22,15 @@ const (
 	urlTree          = "https://api.github.com/repos/hexdigest/gowrap/git/trees/master?recursive=1"
 
 	templatesPathPrefix = "templates/"
+
+	// TokenEnv is the environment variable with the bearer token sent along with the HTTPS requests
+	// for the templates specified by URL, i.e. templates hosted on the internal servers
+	TokenEnv = "GOWRAP_TEMPLATE_TOKEN"
+
+	// TimeoutEnv is the environment variable with the timeout of the requests for the remote templates, i.e. "10s"
+	TimeoutEnv = "GOWRAP_TEMPLATE_TIMEOUT"
+
+	defaultTimeout = 30 * time.Second
 )
 
 type httpClient interface {
@@ -28,22 +41,60 @@ type httpClient interface {
 type Loader struct {
 	client  httpClient
 	gitRoot func() (string, error)
+
+	//templates fetched by URL are cached in the cacheDir, caching is disabled if it's empty
+	cacheDir string
+	token    string
+	timeout  time.Duration
+
+	//timeoutErr is returned by the requests if the TimeoutEnv value can't be parsed
+	timeoutErr error
 }
 
-// New returns Loader
+var errInvalidTimeout = errors.New("invalid " + TimeoutEnv)
+
+// New returns Loader, the token and the timeout of the requests are taken from
+// the TokenEnv and TimeoutEnv environment variables, the invalid timeout is
+// reported by the requests for the remote templates
 func New(client httpClient) Loader {
 	if client == nil {
 		client = http.DefaultClient
 	}
 
-	return Loader{client: client, gitRoot: gitRootPath}
+	timeout := defaultTimeout
+	var timeoutErr error
+	if env := os.Getenv(TimeoutEnv); env != "" {
+		var err error
+		if timeout, err = time.ParseDuration(env); err != nil {
+			timeoutErr = errors.Wrap(errInvalidTimeout, err.Error())
+		}
+	}
+
+	var cacheDir string
+	if userCacheDir, err := os.UserCacheDir(); err == nil {
+		cacheDir = filepath.Join(userCacheDir, "gowrap", "templates")
+	}
+
+	return Loader{
+		client:     client,
+		gitRoot:    gitRootPath,
+		cacheDir:   cacheDir,
+		token:      os.Getenv(TokenEnv),
+		timeout:    timeout,
+		timeoutErr: timeoutErr,
+	}
+}
+
+// isURL returns true if the template path is an HTTP or HTTPS URL
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://")
 }
 
 // Load returns template contents and template URL or error
 // path can be either any HTTPs URL or reference to the template on a project's github page
 func (l Loader) Load(path string) (tmpl []byte, url string, err error) {
-	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
-		body, err := l.get(path)
+	if isURL(path) {
+		body, err := l.fetchURL(path)
 		return body, path, err
 	}
 
@@ -94,10 +145,11 @@ func (l Loader) List() ([]string, error) {
 var errUnexpectedStatusCode = errors.New("unexpected status code")
 
 func (l Loader) get(url string) (b []byte, err error) {
-	req, err := http.NewRequest("GET", url, nil)
+	req, cancel, err := l.newRequest(url)
 	if err != nil {
 		return nil, err
 	}
+	defer cancel()
 
 	resp, err := l.client.Do(req)
 	if err != nil {
@@ -117,6 +169,125 @@ func (l Loader) get(url string) (b []byte, err error) {
 	return io.ReadAll(resp.Body)
 }
 
+func (l Loader) newRequest(url string) (*http.Request, context.CancelFunc, error) {
+	if l.timeoutErr != nil {
+		return nil, nil, l.timeoutErr
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if l.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return req, cancel, nil
+}
+
+// fetchURL fetches the template by URL and caches it. The cached template is
+// used if the template isn't modified since it was cached according to its ETag
+// or if the template can't be fetched because of the network or the server error
+func (l Loader) fetchURL(url string) (b []byte, err error) {
+	cached, etag, cacheErr := l.readCache(url)
+
+	req, cancel, err := l.newRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	//the token is never sent in cleartext
+	if l.token != "" && strings.HasPrefix(url, "https://") {
+		req.Header.Set("Authorization", "Bearer "+l.token)
+	}
+
+	if cacheErr == nil && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode >= http.StatusInternalServerError && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errors.Wrap(ErrTemplateNotFound, url)
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.Wrapf(errUnexpectedStatusCode, "%d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	//failure to cache the template doesn't prevent the generation
+	_ = l.writeCache(url, resp.Header.Get("ETag"), body)
+
+	return body, nil
+}
+
+var errCacheDisabled = errors.New("templates cache is disabled")
+
+// cachePath returns the path of the cached template, the template is stored
+// along with its ETag in the file named with the checksum of the template URL
+func (l Loader) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(l.cacheDir, hex.EncodeToString(sum[:]))
+}
+
+func (l Loader) readCache(url string) (body []byte, etag string, err error) {
+	if l.cacheDir == "" {
+		return nil, "", errCacheDisabled
+	}
+
+	body, err = os.ReadFile(l.cachePath(url))
+	if err != nil {
+		return nil, "", err
+	}
+
+	etagBytes, err := os.ReadFile(l.cachePath(url) + ".etag")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, "", err
+	}
+
+	return body, string(etagBytes), nil
+}
+
+func (l Loader) writeCache(url, etag string, body []byte) error {
+	if l.cacheDir == "" {
+		return errCacheDisabled
+	}
+
+	if err := os.MkdirAll(l.cacheDir, 0700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(l.cachePath(url)+".etag", []byte(etag), 0600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(l.cachePath(url), body, 0600)
+}
+
 func (l Loader) absGitPath(path string) (string, error) {
 	gitRoot, err := l.gitRoot()
 	if err != nil {
@@ -126,7 +297,8 @@ func (l Loader) absGitPath(path string) (string, error) {
 	return filepath.Join(gitRoot, path), nil
 }
 
-var errTemplateNotFound = errors.New("remote template not found")
+// ErrTemplateNotFound is returned when the remote template doesn't exist
+var ErrTemplateNotFound = errors.New("remote template not found")
 
 func (l Loader) fetchFromGithub(templateName string) ([]byte, string, error) {
 	body, err := l.get(fmt.Sprintf(urlFormatCommits, templateName))
@@ -140,7 +312,7 @@ func (l Loader) fetchFromGithub(templateName string) ([]byte, string, error) {
 	}
 
 	if len(commits) == 0 {
-		return nil, "", errTemplateNotFound
+		return nil, "", ErrTemplateNotFound
 	}
 
 	url := fmt.Sprintf(urlFormatRaw, commits[0].SHA, templateName)
@@ -13,11 +13,28 @@ import (
 	minimock "github.com/gojuno/minimock/v3"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
+	t.Setenv(TimeoutEnv, "")
+
 	l := New(nil)
 	assert.Equal(t, Loader{client: http.DefaultClient}.client, l.client)
+	assert.Equal(t, defaultTimeout, l.timeout)
+
+	t.Setenv(TimeoutEnv, "10s")
+
+	l = New(nil)
+	assert.NoError(t, l.timeoutErr)
+	assert.Equal(t, 10*time.Second, l.timeout)
+
+	t.Setenv(TimeoutEnv, "10")
+
+	l = New(nil)
+	_, _, err := l.Load("https://host/template")
+	require.Error(t, err)
+	assert.Equal(t, errInvalidTimeout, errors.Cause(err))
 }
 
 func TestLoader_Load(t *testing.T) {
@@ -338,7 +355,7 @@ func TestLoader_fetchFromGithub(t *testing.T) {
 			},
 			wantErr: true,
 			inspectErr: func(err error, t *testing.T) {
-				assert.Equal(t, errTemplateNotFound, err)
+				assert.Equal(t, ErrTemplateNotFound, err)
 			},
 		},
 		{
@@ -411,3 +428,184 @@ func TestLoader_fetchFromGithub(t *testing.T) {
 		})
 	}
 }
+
+func TestLoader_fetchURL(t *testing.T) {
+	clientError := errors.New("client error")
+
+	const url = "https://host/template"
+
+	cache := func(t *testing.T, etag string) Loader {
+		l := Loader{cacheDir: t.TempDir()}
+		assert.NoError(t, l.writeCache(url, etag, []byte("cached body")))
+		return l
+	}
+
+	tests := []struct {
+		name    string
+		init    func(t *testing.T, mc minimock.Tester) Loader
+		inspect func(r Loader, t *testing.T) //inspects Loader after execution of fetchURL
+
+		want1      []byte
+		wantErr    bool
+		inspectErr func(err error, t *testing.T) //use for more precise error evaluation
+	}{
+		{
+			name: "token is sent",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				client := newHTTPClientMock(mc)
+				client.DoFunc = func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("template body"))}, nil
+				}
+
+				return Loader{client: client, token: "token", timeout: time.Second}
+			},
+			want1: []byte("template body"),
+		},
+		{
+			name: "template is cached",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				client := newHTTPClientMock(mc)
+				client.DoFunc = func(r *http.Request) (*http.Response, error) {
+					assert.Empty(t, r.Header.Get("Authorization"))
+					assert.Empty(t, r.Header.Get("If-None-Match"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Etag": []string{`"v1"`}},
+						Body:       io.NopCloser(strings.NewReader("template body")),
+					}, nil
+				}
+
+				return Loader{client: client, cacheDir: t.TempDir()}
+			},
+			inspect: func(r Loader, t *testing.T) {
+				body, etag, err := r.readCache(url)
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("template body"), body)
+				assert.Equal(t, `"v1"`, etag)
+			},
+			want1: []byte("template body"),
+		},
+		{
+			name: "not modified",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				client := newHTTPClientMock(mc)
+				client.DoFunc = func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+					return &http.Response{StatusCode: http.StatusNotModified, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}
+
+				l := cache(t, `"v1"`)
+				l.client = client
+				return l
+			},
+			want1: []byte("cached body"),
+		},
+		{
+			name: "network error with cached template",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				l := cache(t, "")
+				l.client = newHTTPClientMock(mc).DoMock.Return(nil, clientError)
+				return l
+			},
+			want1: []byte("cached body"),
+		},
+		{
+			name: "network error without cached template",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				return Loader{client: newHTTPClientMock(mc).DoMock.Return(nil, clientError), cacheDir: t.TempDir()}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, clientError, err)
+			},
+		},
+		{
+			name: "server error with cached template",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				r := &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}
+				l := cache(t, "")
+				l.client = newHTTPClientMock(mc).DoMock.Return(r, nil)
+				return l
+			},
+			want1: []byte("cached body"),
+		},
+		{
+			name: "server error without cached template",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				r := &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}
+				return Loader{client: newHTTPClientMock(mc).DoMock.Return(r, nil), cacheDir: t.TempDir()}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUnexpectedStatusCode, errors.Cause(err))
+			},
+		},
+		{
+			name: "template not found",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				r := &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}
+				l := cache(t, "")
+				l.client = newHTTPClientMock(mc).DoMock.Return(r, nil)
+				return l
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, ErrTemplateNotFound, errors.Cause(err))
+			},
+		},
+		{
+			name: "unexpected status code",
+			init: func(t *testing.T, mc minimock.Tester) Loader {
+				r := &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(""))}
+				l := cache(t, "")
+				l.client = newHTTPClientMock(mc).DoMock.Return(r, nil)
+				return l
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUnexpectedStatusCode, errors.Cause(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := minimock.NewController(t)
+			defer mc.Wait(time.Second)
+
+			receiver := tt.init(t, mc)
+
+			got1, err := receiver.fetchURL(url)
+
+			if tt.inspect != nil {
+				tt.inspect(receiver, t)
+			}
+
+			assert.Equal(t, tt.want1, got1, "Loader.fetchURL returned unexpected result")
+
+			if tt.wantErr {
+				if assert.Error(t, err) && tt.inspectErr != nil {
+					tt.inspectErr(err, t)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoader_fetchURL_tokenOverHTTP(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Wait(time.Second)
+
+	client := newHTTPClientMock(mc)
+	client.DoFunc = func(r *http.Request) (*http.Response, error) {
+		assert.Empty(t, r.Header.Get("Authorization"), "the token must not be sent in cleartext")
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("template body"))}, nil
+	}
+
+	got, err := Loader{client: client, token: "token", timeout: time.Second}.fetchURL("http://host/template")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("template body"), got)
+}
@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
 	"path/filepath"
 
 	"golang.org/x/tools/go/packages"
@@ -12,9 +13,12 @@ import (
 
 var errPackageNotFound = errors.New("package not found")
 
-// Load loads package by its import path
-func Load(path string) (*packages.Package, error) {
-	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedDeps}
+// Load loads package by its import path, buildFlags are passed to the build system, i.e. "-tags=integration"
+func Load(path string, buildFlags ...string) (*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports | packages.NeedDeps,
+		BuildFlags: buildFlags,
+	}
 	pkgs, err := packages.Load(cfg, path)
 	if err != nil {
 		return nil, err
@@ -31,11 +35,20 @@ func Load(path string) (*packages.Package, error) {
 	return pkgs[0], nil
 }
 
-// AST returns package's abstract syntax tree
+// AST returns package's abstract syntax tree, files excluded by the build constraints are skipped
 func AST(fs *token.FileSet, p *packages.Package) (*ast.Package, error) {
 	dir := Dir(p)
 
-	pkgs, err := parser.ParseDir(fs, dir, nil, parser.DeclarationErrors|parser.ParseComments)
+	ignored := make(map[string]bool, len(p.IgnoredFiles))
+	for _, file := range p.IgnoredFiles {
+		ignored[filepath.Base(file)] = true
+	}
+
+	filter := func(fi os.FileInfo) bool {
+		return !ignored[fi.Name()]
+	}
+
+	pkgs, err := parser.ParseDir(fs, dir, filter, parser.DeclarationErrors|parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -56,3 +69,22 @@ func Dir(p *packages.Package) string {
 
 	return filepath.Dir(files[0])
 }
+
+// StdinFile is the file name of the source parsed by Parse
+const StdinFile = "<stdin>"
+
+// Parse parses the Go source of a single file into the package with the given import path,
+// the imports of the package are not loaded so the declarations of other packages can't be resolved
+func Parse(fs *token.FileSet, path string, src []byte) (*packages.Package, *ast.Package, error) {
+	f, err := parser.ParseFile(fs, StdinFile, src, parser.DeclarationErrors|parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &packages.Package{
+		Name:    f.Name.Name,
+		PkgPath: path,
+	}
+
+	return p, &ast.Package{Name: f.Name.Name, Files: map[string]*ast.File{StdinFile: f}}, nil
+}
@@ -14,6 +14,7 @@ import (
 type Printer struct {
 	fs          *token.FileSet
 	types       []*ast.TypeSpec
+	consts      []string
 	typesPrefix string
 	buf         *bytes.Buffer
 }
@@ -31,6 +32,13 @@ func New(fs *token.FileSet, typeSpecs []*ast.TypeSpec, typesPrefix string) *Prin
 	}
 }
 
+// SetConsts sets the names of the constants declared in the source package,
+// references to these constants (i.e. in array length expressions) are printed
+// with the source package selector the same way as the types listed in typeSpecs
+func (p *Printer) SetConsts(names []string) {
+	p.consts = names
+}
+
 // Print prints AST node as is
 func (p *Printer) Print(node ast.Node) (string, error) {
 	if node == nil {
@@ -65,8 +73,14 @@ func (p *Printer) PrintType(node ast.Node) (string, error) {
 		return p.printMap(t)
 	case *ast.StructType:
 		return p.printStruct(t)
+	case *ast.InterfaceType:
+		return p.printInterface(t)
 	case *ast.Ident:
 		return p.printIdent(t)
+	case *ast.IndexExpr:
+		return p.printGeneric(t.X, t.Index)
+	case *ast.IndexListExpr:
+		return p.printGeneric(t.X, t.Indices...)
 	}
 
 	err := printer.Fprint(p.buf, p.fs, node)
@@ -79,7 +93,7 @@ func (p *Printer) printArray(a *ast.ArrayType) (string, error) {
 		return "", err
 	}
 
-	l, err := p.Print(a.Len)
+	l, err := p.printArrayLen(a.Len)
 	if err != nil {
 		return "", err
 	}
@@ -87,6 +101,52 @@ func (p *Printer) printArray(a *ast.ArrayType) (string, error) {
 	return "[" + l + "]" + sliceType, nil
 }
 
+func (p *Printer) printArrayLen(e ast.Expr) (string, error) {
+	switch v := e.(type) {
+	case *ast.Ident:
+		return p.printConst(v)
+	case *ast.ParenExpr:
+		x, err := p.printArrayLen(v.X)
+		if err != nil {
+			return "", err
+		}
+		return "(" + x + ")", nil
+	case *ast.UnaryExpr:
+		x, err := p.printArrayLen(v.X)
+		if err != nil {
+			return "", err
+		}
+		return v.Op.String() + x, nil
+	case *ast.BinaryExpr:
+		x, err := p.printArrayLen(v.X)
+		if err != nil {
+			return "", err
+		}
+		y, err := p.printArrayLen(v.Y)
+		if err != nil {
+			return "", err
+		}
+		return x + " " + v.Op.String() + " " + y, nil
+	}
+
+	return p.Print(e)
+}
+
+var errUnexportedConst = errors.New("unexported constant")
+
+func (p *Printer) printConst(i *ast.Ident) (string, error) {
+	for _, name := range p.consts {
+		if i.Name == name && len(p.typesPrefix) > 0 {
+			if []rune(name)[0] == []rune(strings.ToLower(name))[0] {
+				return "", errors.Wrap(errUnexportedConst, name)
+			}
+			return p.typesPrefix + "." + i.Name, nil
+		}
+	}
+
+	return i.Name, nil
+}
+
 var chanTypes = map[ast.ChanDir]string{
 	ast.SEND | ast.RECV: "chan ",
 	ast.SEND:            "chan<- ",
@@ -112,7 +172,17 @@ func (p *Printer) printFunc(ft *ast.FuncType) (string, error) {
 		return "", err
 	}
 
-	return "func(" + strings.Join(params, ", ") + ") (" + strings.Join(results, ", ") + ")", nil
+	return "func(" + joinFields(params) + ") (" + joinFields(results) + ")", nil
+}
+
+// joinFields joins printed fields of the func signature
+// dropping the leading space of the unnamed params and results
+func joinFields(fields []string) string {
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+
+	return strings.Join(fields, ", ")
 }
 
 func (p *Printer) printMap(mt *ast.MapType) (string, error) {
@@ -151,6 +221,24 @@ func (p *Printer) printIdent(i *ast.Ident) (string, error) {
 	return p.buf.String(), err
 }
 
+func (p *Printer) printGeneric(x ast.Expr, indices ...ast.Expr) (string, error) {
+	genericType, err := p.PrintType(x)
+	if err != nil {
+		return "", err
+	}
+
+	params := make([]string, 0, len(indices))
+	for _, index := range indices {
+		param, err := p.PrintType(index)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+	}
+
+	return genericType + "[" + strings.Join(params, ", ") + "]", nil
+}
+
 func (p *Printer) printPointer(pt *ast.StarExpr) (string, error) {
 	pointerTo, err := p.PrintType(pt.X)
 	if err != nil {
@@ -169,6 +257,35 @@ func (p *Printer) printStruct(s *ast.StructType) (string, error) {
 	return "struct{\n" + strings.Join(fields, "\n") + "\n}", nil
 }
 
+// printInterface prints an interface literal, the embedded types and
+// the types in the methods signatures are printed the same way as other types
+func (p *Printer) printInterface(it *ast.InterfaceType) (string, error) {
+	if it.Methods == nil || len(it.Methods.List) == 0 {
+		return "interface{}", nil
+	}
+
+	elems := make([]string, 0, len(it.Methods.List))
+	for _, field := range it.Methods.List {
+		ft, isMethod := field.Type.(*ast.FuncType)
+		if !isMethod || len(field.Names) == 0 {
+			embedded, err := p.PrintType(field.Type)
+			if err != nil {
+				return "", err
+			}
+			elems = append(elems, embedded)
+			continue
+		}
+
+		signature, err := p.printFunc(ft)
+		if err != nil {
+			return "", err
+		}
+		elems = append(elems, field.Names[0].Name+strings.TrimPrefix(signature, "func"))
+	}
+
+	return "interface{\n" + strings.Join(elems, "\n") + "\n}", nil
+}
+
 func (p *Printer) printVariadicParam(e *ast.Ellipsis) (string, error) {
 	sliceType, err := p.PrintType(e.Elt)
 	if err != nil {
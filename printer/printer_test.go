@@ -199,6 +199,89 @@ func TestPrinter_printArray(t *testing.T) {
 			want1:   "[]Exported",
 			wantErr: false,
 		},
+		{
+			name:  "basic literal length",
+			array: &ast.ArrayType{Len: &ast.BasicLit{Kind: token.INT, Value: "16"}, Elt: &ast.Ident{Name: "byte"}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					typesPrefix: "otherPackage",
+				}
+			},
+			want1:   "[16]byte",
+			wantErr: false,
+		},
+		{
+			name:  "constant length",
+			array: &ast.ArrayType{Len: &ast.Ident{Name: "Size"}, Elt: &ast.Ident{Name: "byte"}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					consts:      []string{"Size"},
+					typesPrefix: "otherPackage",
+				}
+			},
+			want1:   "[otherPackage.Size]byte",
+			wantErr: false,
+		},
+		{
+			name:  "unexported constant length",
+			array: &ast.ArrayType{Len: &ast.Ident{Name: "size"}, Elt: &ast.Ident{Name: "byte"}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					consts:      []string{"size"},
+					typesPrefix: "otherPackage",
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUnexportedConst, errors.Cause(err))
+			},
+		},
+		{
+			name: "expression length",
+			array: &ast.ArrayType{
+				Len: &ast.BinaryExpr{
+					X:  &ast.ParenExpr{X: &ast.BinaryExpr{X: &ast.Ident{Name: "Size"}, Op: token.ADD, Y: &ast.BasicLit{Kind: token.INT, Value: "1"}}},
+					Op: token.MUL,
+					Y:  &ast.UnaryExpr{Op: token.SUB, X: &ast.Ident{Name: "Count"}},
+				},
+				Elt: &ast.Ident{Name: "byte"},
+			},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					consts:      []string{"Size", "Count"},
+					typesPrefix: "otherPackage",
+				}
+			},
+			want1:   "[(otherPackage.Size + 1) * -otherPackage.Count]byte",
+			wantErr: false,
+		},
+		{
+			name: "expression length with unexported constant",
+			array: &ast.ArrayType{
+				Len: &ast.BinaryExpr{X: &ast.Ident{Name: "Size"}, Op: token.SHL, Y: &ast.Ident{Name: "shift"}},
+				Elt: &ast.Ident{Name: "byte"},
+			},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					consts:      []string{"Size", "shift"},
+					typesPrefix: "otherPackage",
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUnexportedConst, errors.Cause(err))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -381,6 +464,21 @@ func TestPrinter_printFunc(t *testing.T) {
 			want1:   "func() ()",
 			wantErr: false,
 		},
+		{
+			name: "variadic param",
+			f: &ast.FuncType{
+				Params:  &ast.FieldList{List: []*ast.Field{{Type: &ast.Ellipsis{Elt: &ast.Ident{Name: "int"}}}}},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "error"}}}},
+			},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:  token.NewFileSet(),
+					buf: bytes.NewBuffer([]byte{}),
+				}
+			},
+			want1:   "func(...int) (error)",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -635,6 +733,84 @@ func TestPrinter_printStruct(t *testing.T) {
 	}
 }
 
+func TestPrinter_printInterface(t *testing.T) {
+	reader := &ast.Field{Type: &ast.SelectorExpr{X: &ast.Ident{Name: "io"}, Sel: &ast.Ident{Name: "Reader"}}}
+	name := &ast.Field{
+		Names: []*ast.Ident{{Name: "Name"}},
+		Type:  &ast.FuncType{Params: &ast.FieldList{}, Results: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "string"}}}}},
+	}
+
+	tests := []struct {
+		name string
+		init func(t minimock.Tester) *Printer
+
+		it *ast.InterfaceType
+
+		want1      string
+		wantErr    bool
+		inspectErr func(err error, t *testing.T)
+	}{
+		{
+			name: "embeds an unexported type",
+			it:   &ast.InterfaceType{Methods: &ast.FieldList{List: []*ast.Field{{Type: &ast.Ident{Name: "unexported"}}}}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					types:       []*ast.TypeSpec{{Name: &ast.Ident{Name: "unexported"}}},
+					typesPrefix: "otherPackage",
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.Equal(t, errUnexportedType, errors.Cause(err))
+			},
+		},
+		{
+			name: "empty interface",
+			it:   &ast.InterfaceType{Methods: &ast.FieldList{}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{}
+			},
+			want1: "interface{}",
+		},
+		{
+			name: "embedded interfaces and methods",
+			it:   &ast.InterfaceType{Methods: &ast.FieldList{List: []*ast.Field{reader, {Type: &ast.Ident{Name: "Named"}}, name}}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					types:       []*ast.TypeSpec{{Name: &ast.Ident{Name: "Named"}}},
+					typesPrefix: "source",
+				}
+			},
+			want1: "interface{\nio.Reader\nsource.Named\nName() (string)\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := minimock.NewController(t)
+			defer mc.Wait(time.Second)
+
+			receiver := tt.init(mc)
+
+			got1, err := receiver.printInterface(tt.it)
+
+			assert.Equal(t, tt.want1, got1, "Printer.printInterface returned unexpected result")
+
+			if tt.wantErr {
+				if assert.Error(t, err) && tt.inspectErr != nil {
+					tt.inspectErr(err, t)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestPrinter_printVariadicParam(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -876,6 +1052,35 @@ func TestPrinter_PrintType(t *testing.T) {
 			},
 			want1: "package.Identifier",
 		},
+		{
+			name: "generic type",
+			node: &ast.IndexExpr{X: &ast.Ident{Name: "Stream"}, Index: &ast.Ident{Name: "T"}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					types:       []*ast.TypeSpec{{Name: &ast.Ident{Name: "Stream"}}},
+					typesPrefix: "source",
+				}
+			},
+			want1: "source.Stream[T]",
+		},
+		{
+			name: "generic type with several params",
+			node: &ast.IndexListExpr{X: &ast.Ident{Name: "Store"}, Indices: []ast.Expr{
+				&ast.Ident{Name: "K"},
+				&ast.StarExpr{X: &ast.Ident{Name: "Value"}},
+			}},
+			init: func(t minimock.Tester) *Printer {
+				return &Printer{
+					fs:          token.NewFileSet(),
+					buf:         bytes.NewBuffer([]byte{}),
+					types:       []*ast.TypeSpec{{Name: &ast.Ident{Name: "Store"}}, {Name: &ast.Ident{Name: "Value"}}},
+					typesPrefix: "source",
+				}
+			},
+			want1: "source.Store[K, *source.Value]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,12 +1,21 @@
 package templatestests
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // TestInterface is used to test templates
 type TestInterface interface {
+	// F accepts a context and variadic params,
+	// it returns several results and an error
 	F(ctx context.Context, a1 string, a2 ...string) (result1, result2 string, err error)
 	ContextNoError(ctx context.Context, a1 string, a2 string)
 	NoError(string) string
+
+	// NoParamsOrResults has neither params nor results.
+	//
+	// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
 	NoParamsOrResults()
 	Channels(chA chan bool, chB chan<- bool, chanC <-chan bool)
 }
@@ -18,3 +27,65 @@ type AnotherTestInterface interface {
 	NoParamsOrResults()
 	Channels(chA chan bool, chB chan<- bool, chanC <-chan bool)
 }
+
+// CloserTestInterface is used to test templates handling the Close method
+type CloserTestInterface interface {
+	NoError(string) string
+	Close() error
+}
+
+// PrimaryEmbeddedTestInterface is used to test templates forwarding the methods of the primary embedded interface
+type PrimaryEmbeddedTestInterface interface {
+	io.Closer
+	NoError(string) string
+}
+
+// ErrorsTestInterface is used to test templates handling several methods returning errors
+type ErrorsTestInterface interface {
+	First(s string) error
+	Second(s string) error
+	NoError(string) string
+}
+
+// FuncTestInterface is used to test templates requiring an interface with a single method
+type FuncTestInterface interface {
+	Do(ctx context.Context, a1 string, a2 ...string) (result string, err error)
+}
+
+// GenericTestInterface is used to test templates generating decorators of the generic interfaces
+type GenericTestInterface[K comparable, V any] interface {
+	Get(ctx context.Context, k K) (V, error)
+}
+
+// HealthTestInterface is used to test templates detecting the health check methods
+type HealthTestInterface interface {
+	Ping(ctx context.Context) error
+	Ready() error
+	Get(ctx context.Context, key string) (value string, err error)
+}
+
+// KeyValueTestInterface is used to test templates deduplicating the calls with the same arguments
+type KeyValueTestInterface interface {
+	Get(ctx context.Context, key string) (value string, err error)
+	GetMany(ctx context.Context, keys []string) (values []string, err error)
+	Set(ctx context.Context, key, value string) error
+}
+
+// RateTestInterface is used to test templates handling the methods with and without a context or an error
+type RateTestInterface interface {
+	Get(ctx context.Context, key string) (value string, err error)
+	Set(key, value string) error
+	Touch(ctx context.Context, key string)
+	Len() int
+}
+
+// WatcherTestInterface is used to test templates handling the methods returning channels
+type WatcherTestInterface interface {
+	Get(ctx context.Context, key string) (value string, err error)
+	Watch(ctx context.Context, key string) (<-chan string, error)
+}
+
+// testValidator is called by the decorators generated with the validator template, tests replace it
+var testValidator = func(method string, params ...interface{}) error {
+	return nil
+}
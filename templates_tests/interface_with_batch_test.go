@@ -0,0 +1,92 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type kvStoreImpl struct {
+	values map[string]string
+	err    error
+}
+
+func (k *kvStoreImpl) Get(ctx context.Context, key string) (string, error) {
+	return k.values[key], k.err
+}
+
+func (k *kvStoreImpl) GetMany(ctx context.Context, keys []string) ([]string, error) {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, k.values[key])
+	}
+	return values, k.err
+}
+
+func (k *kvStoreImpl) Set(ctx context.Context, key, value string) error {
+	if k.err != nil {
+		return k.err
+	}
+	k.values[key] = value
+	return nil
+}
+
+func TestKeyValueTestInterfaceWithBatching_Set(t *testing.T) {
+	impl := &kvStoreImpl{values: map[string]string{}}
+
+	var flushed [][]KeyValueTestInterfaceWithBatchingSetCall
+	var flushErr error
+	wrapped := NewKeyValueTestInterfaceWithBatching(impl, 2, func(ctx context.Context, calls []KeyValueTestInterfaceWithBatchingSetCall) error {
+		flushed = append(flushed, calls)
+		return flushErr
+	})
+
+	require.NoError(t, wrapped.Set(context.Background(), "k1", "v1"))
+	assert.Empty(t, flushed, "the batch must not be flushed until it's full")
+
+	flushErr = errors.New("flush error")
+	assert.Equal(t, flushErr, wrapped.Set(context.Background(), "k2", "v2"))
+	assert.Equal(t, [][]KeyValueTestInterfaceWithBatchingSetCall{{{Key: "k1", Value: "v1"}, {Key: "k2", Value: "v2"}}}, flushed)
+
+	flushErr = nil
+	require.NoError(t, wrapped.Set(context.Background(), "k3", "v3"))
+	require.NoError(t, wrapped.Flush(context.Background()))
+	assert.Equal(t, []KeyValueTestInterfaceWithBatchingSetCall{{Key: "k3", Value: "v3"}}, flushed[1])
+
+	require.NoError(t, wrapped.Flush(context.Background()))
+	assert.Len(t, flushed, 2, "empty batches must not be flushed")
+
+	assert.Empty(t, impl.values, "the calls must be passed to the flush func only")
+}
+
+func TestKeyValueTestInterfaceWithBatching_NilFlushFunc(t *testing.T) {
+	impl := &kvStoreImpl{values: map[string]string{}}
+	wrapped := NewKeyValueTestInterfaceWithBatching(impl, 3, nil)
+
+	require.NoError(t, wrapped.Set(context.Background(), "k1", "v1"))
+	require.NoError(t, wrapped.Set(context.Background(), "k2", "v2"))
+	assert.Empty(t, impl.values)
+
+	require.NoError(t, wrapped.Flush(context.Background()))
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "v2"}, impl.values)
+
+	impl.err = errors.New("unexpected error")
+	require.NoError(t, wrapped.Set(context.Background(), "k3", "v3"))
+	assert.True(t, errors.Is(wrapped.Flush(context.Background()), impl.err))
+}
+
+func TestKeyValueTestInterfaceWithBatching_Passthrough(t *testing.T) {
+	impl := &kvStoreImpl{values: map[string]string{"k1": "v1", "k2": "v2"}}
+	wrapped := NewKeyValueTestInterfaceWithBatching(impl, 2, nil)
+
+	value, err := wrapped.Get(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", value)
+
+	values, err := wrapped.GetMany(context.Background(), []string{"k1", "k2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1", "v2"}, values)
+}
@@ -36,6 +36,9 @@ func NewTestInterfaceWithCircuitBreaker(base TestInterface, consecutiveErrors in
 	}
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d *TestInterfaceWithCircuitBreaker) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_d._lock.RLock()
@@ -0,0 +1,56 @@
+package templatestests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closerImpl struct {
+	closed bool
+	err    error
+}
+
+func (c *closerImpl) NoError(s string) string {
+	return s
+}
+
+func (c *closerImpl) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestCloserTestInterfaceWithCloser_Close(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		impl := &closerImpl{}
+		resource := &closerImpl{}
+		wrapped := NewCloserTestInterfaceWithCloser(impl, resource)
+
+		assert.NoError(t, wrapped.Close())
+		assert.True(t, impl.closed)
+		assert.True(t, resource.closed)
+	})
+
+	t.Run("errors are joined", func(t *testing.T) {
+		errBase := errors.New("base error")
+		errResource := errors.New("resource error")
+
+		impl := &closerImpl{err: errBase}
+		resource1 := &closerImpl{}
+		resource2 := &closerImpl{err: errResource}
+		wrapped := NewCloserTestInterfaceWithCloser(impl, resource1, resource2)
+
+		err := wrapped.Close()
+		assert.ErrorIs(t, err, errBase)
+		assert.ErrorIs(t, err, errResource)
+		assert.True(t, impl.closed)
+		assert.True(t, resource1.closed)
+		assert.True(t, resource2.closed)
+	})
+
+	t.Run("other methods are delegated", func(t *testing.T) {
+		wrapped := NewCloserTestInterfaceWithCloser(&closerImpl{})
+		assert.Equal(t, "a", wrapped.NoError("a"))
+	})
+}
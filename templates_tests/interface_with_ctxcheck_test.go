@@ -0,0 +1,41 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithContextCheck_F(t *testing.T) {
+	t.Run("active context", func(t *testing.T) {
+		impl := &testImpl{r1: "1", r2: "2"}
+		wrapped := NewTestInterfaceWithContextCheck(impl)
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+		assert.EqualValues(t, 1, impl.callCounter)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		impl := &testImpl{r1: "1", r2: "2"}
+		wrapped := NewTestInterfaceWithContextCheck(impl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r1, r2, err := wrapped.F(ctx, "a1")
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, r1)
+		assert.Empty(t, r2)
+		assert.EqualValues(t, 0, impl.callCounter)
+	})
+}
+
+func TestTestInterfaceWithContextCheck_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithContextCheck(&testImpl{})
+	assert.Equal(t, "a", wrapped.NoError("a"))
+}
@@ -61,6 +61,12 @@ func NewTestInterfaceAPMTracing(base TestInterface, opts ...TestInterfaceAPMTrac
 	return r
 }
 
+// Channels implements TestInterface
+func (_d TestInterfaceAPMTracing) Channels(chA chan bool, chB chan<- bool, chanC <-chan bool) {
+	_d.base.Channels(chA, chB, chanC)
+	return
+}
+
 // ContextNoError implements TestInterface
 func (_d TestInterfaceAPMTracing) ContextNoError(ctx context.Context, a1 string, a2 string) {
 	span, ctx := _d.startSpan(ctx, "testinterface.ContextNoError", _d.spanType)
@@ -74,6 +80,9 @@ func (_d TestInterfaceAPMTracing) ContextNoError(ctx context.Context, a1 string,
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceAPMTracing) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	span, ctx := _d.startSpan(ctx, "testinterface.F", _d.spanType)
@@ -88,3 +97,18 @@ func (_d TestInterfaceAPMTracing) F(ctx context.Context, a1 string, a2 ...string
 
 	return _d.base.F(ctx, a1, a2...)
 }
+
+// NoError implements TestInterface
+func (_d TestInterfaceAPMTracing) NoError(s1 string) (s2 string) {
+	return _d.base.NoError(s1)
+}
+
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
+// NoParamsOrResults implements TestInterface
+func (_d TestInterfaceAPMTracing) NoParamsOrResults() {
+	_d.base.NoParamsOrResults()
+	return
+}
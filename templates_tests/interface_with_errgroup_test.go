@@ -0,0 +1,49 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithErrgroup_F(t *testing.T) {
+	t.Run("all implementations are called", func(t *testing.T) {
+		impl1 := &testImpl{r1: "1", r2: "2"}
+		impl2 := &testImpl{r1: "3", r2: "4"}
+		wrapped := NewTestInterfaceWithErrgroup(impl1, impl2)
+
+		r1, r2, err := wrapped.F(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+		assert.EqualValues(t, 1, impl1.callCounter)
+		assert.EqualValues(t, 1, impl2.callCounter)
+	})
+
+	t.Run("first error cancels other implementations", func(t *testing.T) {
+		errImpl := errors.New("implementation error")
+		slow := &testImpl{r1: "1", delay: time.Minute}
+		failing := &testImpl{err: errImpl}
+		wrapped := NewTestInterfaceWithErrgroup(slow, failing)
+
+		_, _, err := wrapped.F(context.Background(), "")
+		assert.Equal(t, errImpl, err)
+		assert.EqualValues(t, 1, slow.callCounter)
+	})
+
+	t.Run("no implementations", func(t *testing.T) {
+		r1, r2, err := NewTestInterfaceWithErrgroup().F(context.Background(), "")
+		require.NoError(t, err)
+		assert.Empty(t, r1)
+		assert.Empty(t, r2)
+	})
+}
+
+func TestTestInterfaceWithErrgroup_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithErrgroup(&testImpl{}, &testImpl{})
+	assert.Equal(t, "a", wrapped.NoError("a"))
+}
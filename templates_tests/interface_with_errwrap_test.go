@@ -0,0 +1,37 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestInterfaceWithErrWrap_F(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		wrapped := NewTestInterfaceWithErrWrap(&testImpl{r1: "1", r2: "2"})
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errUnexpected := errors.New("unexpected error")
+		wrapped := NewTestInterfaceWithErrWrap(&testImpl{r1: "1", r2: "2", err: errUnexpected})
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		assert.True(t, errors.Is(err, errUnexpected))
+		assert.EqualError(t, err, "TestInterface.F: unexpected error")
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+	})
+}
+
+func TestTestInterfaceWithErrWrap_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithErrWrap(&testImpl{})
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+}
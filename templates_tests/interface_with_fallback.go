@@ -98,6 +98,9 @@ func (_d TestInterfaceWithFallback) ContextNoError(ctx context.Context, a1 strin
 
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithFallback) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	type _resultStruct struct {
@@ -187,6 +190,10 @@ func (_d TestInterfaceWithFallback) NoError(s1 string) (s2 string) {
 
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d TestInterfaceWithFallback) NoParamsOrResults() {
 	type _resultStruct struct {
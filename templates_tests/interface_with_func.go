@@ -0,0 +1,24 @@
+// Code generated by gowrap. DO NOT EDIT.
+// template: ../templates/func
+// gowrap: http://github.com/hexdigest/gowrap
+
+package templatestests
+
+import "context"
+
+//go:generate gowrap gen -p github.com/hexdigest/gowrap/templates_tests -i FuncTestInterface -t ../templates/func -o interface_with_func.go -l ""
+
+// FuncTestInterfaceWithFunc implements FuncTestInterface by calling the function passed to the constructor
+type FuncTestInterfaceWithFunc struct {
+	_fn func(ctx context.Context, a1 string, a2 ...string) (result string, err error)
+}
+
+// NewFuncTestInterfaceWithFunc returns FuncTestInterfaceWithFunc calling fn when the Do method is called
+func NewFuncTestInterfaceWithFunc(fn func(ctx context.Context, a1 string, a2 ...string) (result string, err error)) FuncTestInterfaceWithFunc {
+	return FuncTestInterfaceWithFunc{_fn: fn}
+}
+
+// Do implements FuncTestInterface
+func (_d FuncTestInterfaceWithFunc) Do(ctx context.Context, a1 string, a2 ...string) (result string, err error) {
+	return _d._fn(ctx, a1, a2...)
+}
@@ -0,0 +1,25 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncTestInterfaceWithFunc_Do(t *testing.T) {
+	var _ FuncTestInterface = FuncTestInterfaceWithFunc{}
+
+	fnErr := errors.New("fn error")
+
+	wrapped := NewFuncTestInterfaceWithFunc(func(ctx context.Context, a1 string, a2 ...string) (string, error) {
+		assert.Equal(t, "a1", a1)
+		assert.Equal(t, []string{"a2", "a3"}, a2)
+		return "result", fnErr
+	})
+
+	result, err := wrapped.Do(context.Background(), "a1", "a2", "a3")
+	assert.Equal(t, "result", result)
+	assert.Equal(t, fnErr, err)
+}
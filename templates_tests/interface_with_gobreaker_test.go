@@ -0,0 +1,46 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithGobreaker_F(t *testing.T) {
+	impl := &testImpl{r1: "1", r2: "2"}
+	wrapped := NewTestInterfaceWithGobreaker(impl, gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= 2
+		},
+	}))
+
+	r1, r2, err := wrapped.F(context.Background(), "a1", "a2")
+	require.NoError(t, err)
+	assert.Equal(t, "1", r1)
+	assert.Equal(t, "2", r2)
+
+	impl.err = errors.New("unexpected error")
+	for i := 0; i < 2; i++ {
+		r1, r2, err = wrapped.F(context.Background(), "a1")
+		assert.Equal(t, impl.err, err)
+		assert.Equal(t, "1", r1, "the results must be returned along with the error")
+		assert.Equal(t, "2", r2)
+	}
+
+	r1, r2, err = wrapped.F(context.Background(), "a1")
+	assert.Equal(t, gobreaker.ErrOpenState, err)
+	assert.Empty(t, r1)
+	assert.Empty(t, r2)
+	assert.EqualValues(t, 3, atomic.LoadUint64(&impl.callCounter), "the call must not reach the implementation when the circuit is open")
+}
+
+func TestTestInterfaceWithGobreaker_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithGobreaker(&testImpl{}, gobreaker.NewCircuitBreaker(gobreaker.Settings{}))
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+}
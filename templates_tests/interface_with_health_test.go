@@ -0,0 +1,56 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type healthTestImpl struct {
+	pingErr, readyErr error
+	pingCtx           context.Context
+}
+
+func (h *healthTestImpl) Ping(ctx context.Context) error {
+	h.pingCtx = ctx
+	return h.pingErr
+}
+
+func (h *healthTestImpl) Ready() error {
+	return h.readyErr
+}
+
+func (h *healthTestImpl) Get(ctx context.Context, key string) (string, error) {
+	return key, nil
+}
+
+func TestHealthTestInterfaceWithHealth_Healthy(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	t.Run("healthy", func(t *testing.T) {
+		impl := &healthTestImpl{}
+
+		assert.NoError(t, NewHealthTestInterfaceWithHealth(impl).Healthy(ctx))
+		assert.Equal(t, ctx, impl.pingCtx)
+	})
+
+	t.Run("one check fails", func(t *testing.T) {
+		impl := &healthTestImpl{readyErr: errors.New("not ready")}
+
+		err := NewHealthTestInterfaceWithHealth(impl).Healthy(ctx)
+		assert.True(t, errors.Is(err, impl.readyErr))
+		assert.EqualError(t, err, "Ready: not ready")
+	})
+
+	t.Run("all checks fail", func(t *testing.T) {
+		impl := &healthTestImpl{pingErr: errors.New("connection refused"), readyErr: errors.New("not ready")}
+
+		err := NewHealthTestInterfaceWithHealth(impl).Healthy(ctx)
+		assert.True(t, errors.Is(err, impl.pingErr))
+		assert.True(t, errors.Is(err, impl.readyErr))
+		assert.EqualError(t, err, "Ping: connection refused\nReady: not ready")
+	})
+}
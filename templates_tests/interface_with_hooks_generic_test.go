@@ -0,0 +1,33 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type genericTestImpl map[string]int
+
+func (g genericTestImpl) Get(ctx context.Context, k string) (int, error) {
+	return g[k], nil
+}
+
+func TestNewGenericTestInterfaceWithHooks(t *testing.T) {
+	var calls []string
+
+	var wrapped GenericTestInterface[string, int] = NewGenericTestInterfaceWithHooks[string, int](genericTestImpl{"key": 1},
+		GenericTestInterfaceWithHooksWithBefore[string, int](func(method string) {
+			calls = append(calls, "before "+method)
+		}),
+		GenericTestInterfaceWithHooksWithAfter[string, int](func(method string, err error) {
+			calls = append(calls, "after "+method)
+		}),
+	)
+
+	v, err := wrapped.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, []string{"before Get", "after Get"}, calls)
+}
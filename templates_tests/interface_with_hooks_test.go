@@ -0,0 +1,42 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTestInterfaceWithHooks(t *testing.T) {
+	t.Run("no options", func(t *testing.T) {
+		wrapped := NewTestInterfaceWithHooks(&testImpl{})
+
+		assert.Equal(t, "value", wrapped.NoError("value"))
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		var calls []string
+		var afterErr error
+
+		errUnexpected := errors.New("unexpected error")
+		wrapped := NewTestInterfaceWithHooks(&testImpl{err: errUnexpected},
+			TestInterfaceWithHooksWithBefore(func(method string) {
+				calls = append(calls, "before "+method)
+			}),
+			TestInterfaceWithHooksWithAfter(func(method string, err error) {
+				calls = append(calls, "after "+method)
+				afterErr = err
+			}),
+		)
+
+		_, _, err := wrapped.F(context.Background(), "a1")
+		assert.Equal(t, errUnexpected, err)
+		assert.Equal(t, errUnexpected, afterErr)
+
+		wrapped.NoParamsOrResults()
+		assert.NoError(t, afterErr)
+
+		assert.Equal(t, []string{"before F", "after F", "before NoParamsOrResults", "after NoParamsOrResults"}, calls)
+	})
+}
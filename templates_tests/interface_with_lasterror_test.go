@@ -0,0 +1,63 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestInterfaceWithLastError_F(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		wrapped := NewTestInterfaceWithLastError(&testImpl{r1: "1", r2: "2"})
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+		assert.NoError(t, wrapped.LastError("F"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errUnexpected := errors.New("unexpected error")
+		impl := &testImpl{r1: "1", r2: "2", err: errUnexpected}
+		wrapped := NewTestInterfaceWithLastError(impl)
+
+		_, _, err := wrapped.F(context.Background(), "a1")
+		assert.Equal(t, errUnexpected, err)
+		assert.Equal(t, errUnexpected, wrapped.LastError("F"))
+
+		impl.err = nil
+		_, _, err = wrapped.F(context.Background(), "a1")
+		assert.NoError(t, err)
+		assert.Equal(t, errUnexpected, wrapped.LastError("F"), "successful call keeps the last error")
+	})
+}
+
+func TestTestInterfaceWithLastError_LastError(t *testing.T) {
+	wrapped := NewTestInterfaceWithLastError(&testImpl{})
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	assert.NoError(t, wrapped.LastError("NoError"))
+	assert.NoError(t, wrapped.LastError("Unknown"))
+}
+
+func TestTestInterfaceWithLastError_Reset(t *testing.T) {
+	errUnexpected := errors.New("unexpected error")
+	wrapped := NewTestInterfaceWithLastError(&testImpl{err: errUnexpected})
+
+	_, _, err := wrapped.F(context.Background(), "a1")
+	assert.Equal(t, errUnexpected, err)
+
+	impl := &testImpl{r1: "1"}
+	wrapped.Reset(impl)
+
+	assert.Equal(t, impl, wrapped.TestInterface)
+	assert.NoError(t, wrapped.LastError("F"), "recorded errors are cleared")
+
+	r1, _, err := wrapped.F(context.Background(), "a1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", r1)
+	assert.EqualValues(t, 1, impl.callCounter)
+}
@@ -49,6 +49,9 @@ func (_d TestInterfaceWithLogger) ContextNoError(ctx context.Context, a1 string,
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithLogger) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_params := []interface{}{"TestInterfaceWithLogger: calling F with params:", ctx, a1, a2}
@@ -75,6 +78,10 @@ func (_d TestInterfaceWithLogger) NoError(s1 string) (s2 string) {
 	return _d._base.NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d TestInterfaceWithLogger) NoParamsOrResults() {
 	_d._stdlog.Println("TestInterfaceWithLogger: calling NoParamsOrResults")
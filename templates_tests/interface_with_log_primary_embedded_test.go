@@ -0,0 +1,30 @@
+package templatestests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryEmbeddedTestInterfaceWithLog(t *testing.T) {
+	impl := &closerImpl{}
+
+	stdLog := bytes.NewBuffer([]byte{})
+	errLog := bytes.NewBuffer([]byte{})
+
+	wrapped := NewPrimaryEmbeddedTestInterfaceWithLog(impl, stdLog, errLog)
+
+	t.Run("primary method is not logged", func(t *testing.T) {
+		require.NoError(t, wrapped.Close())
+		assert.True(t, impl.closed)
+		assert.Empty(t, stdLog.String())
+		assert.Empty(t, errLog.String())
+	})
+
+	t.Run("other methods are logged", func(t *testing.T) {
+		assert.Equal(t, "param", wrapped.NoError("param"))
+		assert.Contains(t, stdLog.String(), "PrimaryEmbeddedTestInterfaceWithLog: calling NoError with params: param")
+	})
+}
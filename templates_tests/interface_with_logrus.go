@@ -52,6 +52,9 @@ func (_d TestInterfaceWithLogrus) ContextNoError(ctx context.Context, a1 string,
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithLogrus) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_d._log.WithFields(logrus.Fields(map[string]interface{}{
@@ -85,6 +88,10 @@ func (_d TestInterfaceWithLogrus) NoError(s1 string) (s2 string) {
 	return _d._base.NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d TestInterfaceWithLogrus) NoParamsOrResults() {
 	_d._log.Debug("TestInterfaceWithLogrus: calling NoParamsOrResults")
@@ -0,0 +1,45 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithMiddleware_F(t *testing.T) {
+	var calls []string
+
+	middleware := func(name string) TestInterfaceWithMiddlewareMiddleware {
+		return func(method string, next func()) {
+			calls = append(calls, name+" before "+method)
+			next()
+			calls = append(calls, name+" after "+method)
+		}
+	}
+
+	inner := NewTestInterfaceWithMiddleware(&testImpl{r1: "1", r2: "2"}, middleware("inner"))
+	outer := NewTestInterfaceWithMiddleware(inner, middleware("first"), middleware("second"))
+
+	r1, r2, err := outer.F(context.Background(), "a1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", r1)
+	assert.Equal(t, "2", r2)
+
+	assert.Equal(t, []string{
+		"first before F",
+		"second before F",
+		"inner before F",
+		"inner after F",
+		"second after F",
+		"first after F",
+	}, calls)
+}
+
+func TestTestInterfaceWithMiddleware_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithMiddleware(&testImpl{})
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	assert.Equal(t, "value", wrapped.TestInterface.NoError("value"))
+}
@@ -49,6 +49,9 @@ func (_d TestInterfaceWithOpenCensus) ContextNoError(ctx context.Context, a1 str
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithOpenCensus) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	ctx, _span := trace.StartSpan(ctx, _d._instance+".TestInterface.F")
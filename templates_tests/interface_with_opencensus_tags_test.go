@@ -0,0 +1,48 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+)
+
+type tagsImpl struct {
+	testImpl
+	tags *tag.Map
+}
+
+func (t *tagsImpl) ContextNoError(ctx context.Context, a1 string, a2 string) {
+	t.tags = tag.FromContext(ctx)
+}
+
+func TestTestInterfaceWithTags_ContextNoError(t *testing.T) {
+	tenant, region, user := tag.MustNewKey("tenant"), tag.MustNewKey("region"), tag.MustNewKey("user")
+
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(tenant, "acme"),
+		tag.Insert(user, "john"),
+	)
+	require.NoError(t, err)
+
+	impl := &tagsImpl{}
+	NewTestInterfaceWithTags(impl).ContextNoError(ctx, "a1", "a2")
+
+	value, ok := impl.tags.Value(tenant)
+	assert.True(t, ok)
+	assert.Equal(t, "acme", value)
+
+	_, ok = impl.tags.Value(region)
+	assert.False(t, ok, "missing tag is propagated")
+
+	_, ok = impl.tags.Value(user)
+	assert.False(t, ok, "tag that is not listed in TagKeys is propagated")
+}
+
+func TestTestInterfaceWithTags_NoError(t *testing.T) {
+	wrapped := NewTestInterfaceWithTags(&testImpl{})
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+}
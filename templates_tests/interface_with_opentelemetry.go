@@ -11,6 +11,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -18,6 +19,7 @@ import (
 type TestInterfaceWithOpentelemetry struct {
 	TestInterface
 	_instance      string
+	_tracer        trace.Tracer
 	_spanDecorator func(span trace.Span, params, results map[string]interface{})
 }
 
@@ -35,9 +37,26 @@ func NewTestInterfaceWithOpentelemetry(base TestInterface, instance string, span
 	return d
 }
 
+// NewTestInterfaceWithOpentelemetryWithTracer returns TestInterfaceWithOpentelemetry that starts spans with the given tracer
+// instead of the tracer of the global provider
+func NewTestInterfaceWithOpentelemetryWithTracer(base TestInterface, tracer trace.Tracer, spanDecorator ...func(span trace.Span, params, results map[string]interface{})) TestInterfaceWithOpentelemetry {
+	d := NewTestInterfaceWithOpentelemetry(base, "", spanDecorator...)
+	d._tracer = tracer
+
+	return d
+}
+
+func (_d TestInterfaceWithOpentelemetry) tracer() trace.Tracer {
+	if _d._tracer != nil {
+		return _d._tracer
+	}
+
+	return otel.Tracer(_d._instance)
+}
+
 // ContextNoError implements TestInterface
 func (_d TestInterfaceWithOpentelemetry) ContextNoError(ctx context.Context, a1 string, a2 string) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "TestInterface.ContextNoError")
+	ctx, _span := _d.tracer().Start(ctx, "TestInterface.ContextNoError")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -51,9 +70,12 @@ func (_d TestInterfaceWithOpentelemetry) ContextNoError(ctx context.Context, a1
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithOpentelemetry) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "TestInterface.F")
+	ctx, _span := _d.tracer().Start(ctx, "TestInterface.F")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -65,6 +87,7 @@ func (_d TestInterfaceWithOpentelemetry) F(ctx context.Context, a1 string, a2 ..
 				"err":     err})
 		} else if err != nil {
 			_span.RecordError(err)
+			_span.SetStatus(codes.Error, err.Error())
 			_span.SetAttributes(
 				attribute.String("event", "error"),
 				attribute.String("message", err.Error()),
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func TestTestInterfaceWithOpenTelemetryTracing_F(t *testing.T) {
@@ -47,6 +48,7 @@ func TestTestInterfaceWithOpenTelemetryTracing_F(t *testing.T) {
 
 		span := NewOpentelemetrySpanMock(mc)
 		span.RecordErrorMock.Expect(err)
+		span.SetStatusMock.Expect(codes.Error, err.Error()).Return()
 		span.EndMock.Expect().Return()
 		span.SetAttributesMock.
 			Inspect(func(kv ...attribute.KeyValue) {
@@ -71,4 +73,26 @@ func TestTestInterfaceWithOpenTelemetryTracing_F(t *testing.T) {
 		assert.Equal(t, "2", r2)
 
 	})
+
+	t.Run("injected tracer", func(t *testing.T) {
+		impl := &testImpl{r1: "1", r2: "2"}
+
+		mc := minimock.NewController(t)
+		defer mc.Finish()
+
+		span := NewOpentelemetrySpanMock(mc)
+		span.EndMock.Expect().Return()
+
+		tr := NewOpentelemetryTracerMock(mc)
+		tr.StartMock.Expect(context.Background(), "TestInterface.F").Return(context.Background(), span)
+
+		otel.SetTracerProvider(NewOpentelemetryTracerProviderMock(mc))
+
+		wrapped := NewTestInterfaceWithOpentelemetryWithTracer(impl, tr)
+
+		r1, r2, err := wrapped.F(context.Background(), "a1", "a2")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+	})
 }
@@ -51,6 +51,9 @@ func (_d TestInterfaceWithTracing) ContextNoError(ctx context.Context, a1 string
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithTracing) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_span, ctx := opentracing.StartSpanFromContext(ctx, _d._instance+".TestInterface.F")
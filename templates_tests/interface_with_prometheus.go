@@ -60,6 +60,9 @@ func (_d TestInterfaceWithPrometheus) ContextNoError(ctx context.Context, a1 str
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithPrometheus) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_since := time.Now()
@@ -84,6 +87,10 @@ func (_d TestInterfaceWithPrometheus) NoError(s1 string) (s2 string) {
 	return _d.base.NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d TestInterfaceWithPrometheus) NoParamsOrResults() {
 	_since := time.Now()
@@ -0,0 +1,54 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithPrometheusCollector(t *testing.T) {
+	impl := &testImpl{r1: "1", r2: "2"}
+	wrapped := NewTestInterfaceWithPrometheusCollector(impl, "test")
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(wrapped))
+	require.NoError(t, registry.Register(NewTestInterfaceWithPrometheusCollector(impl, "another")))
+
+	r1, r2, err := wrapped.F(context.Background(), "a1", "a2")
+	require.NoError(t, err)
+	assert.Equal(t, "1", r1)
+	assert.Equal(t, "2", r2)
+
+	impl.err = errors.New("unexpected error")
+	_, _, err = wrapped.F(context.Background(), "a1")
+	assert.Error(t, err)
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(wrapped._calls.WithLabelValues("F", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(wrapped._calls.WithLabelValues("F", "error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(wrapped._calls.WithLabelValues("NoError", "ok")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(wrapped._calls.WithLabelValues("NoParamsOrResults", "ok")))
+
+	expected := `
+# HELP gowrap_test_interface_calls_total test_interface calls by method and result
+# TYPE gowrap_test_interface_calls_total counter
+gowrap_test_interface_calls_total{instance_name="test",method="Channels",result="ok"} 0
+gowrap_test_interface_calls_total{instance_name="test",method="ContextNoError",result="ok"} 0
+gowrap_test_interface_calls_total{instance_name="test",method="F",result="error"} 1
+gowrap_test_interface_calls_total{instance_name="test",method="F",result="ok"} 1
+gowrap_test_interface_calls_total{instance_name="test",method="NoError",result="ok"} 1
+gowrap_test_interface_calls_total{instance_name="test",method="NoParamsOrResults",result="ok"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(wrapped, strings.NewReader(expected), "gowrap_test_interface_calls_total"))
+
+	count, err := testutil.GatherAndCount(registry, "gowrap_test_interface_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 10, count)
+}
@@ -0,0 +1,112 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watcherTestImpl struct {
+	err error
+}
+
+func (w watcherTestImpl) Get(ctx context.Context, key string) (string, error) {
+	return key, w.err
+}
+
+func (w watcherTestImpl) Watch(ctx context.Context, key string) (<-chan string, error) {
+	ch := make(chan string, 1)
+	ch <- key
+	return ch, w.err
+}
+
+func newTestHistogram(labels ...string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_duration_seconds", Help: "test"}, labels)
+}
+
+func TestNewWatcherTestInterfaceWithPrometheusHistogram(t *testing.T) {
+	t.Run("observations", func(t *testing.T) {
+		impl := &watcherTestImpl{}
+		histogram := newTestHistogram("method", "success")
+		wrapped := NewWatcherTestInterfaceWithPrometheusHistogram(impl, histogram)
+
+		value, err := wrapped.Get(context.Background(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, "key", value)
+
+		impl.err = errors.New("unexpected error")
+		_, err = wrapped.Get(context.Background(), "key")
+		assert.Error(t, err)
+		_, err = wrapped.Get(context.Background(), "key")
+		assert.Error(t, err)
+
+		ch, err := wrapped.Watch(context.Background(), "watched")
+		assert.Error(t, err)
+		assert.Equal(t, "watched", <-ch)
+
+		registry := prometheus.NewRegistry()
+		require.NoError(t, registry.Register(histogram))
+
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		require.Len(t, families, 1)
+
+		counts := map[string]uint64{}
+		for _, m := range families[0].GetMetric() {
+			var method, success string
+			for _, label := range m.GetLabel() {
+				switch label.GetName() {
+				case "method":
+					method = label.GetValue()
+				case "success":
+					success = label.GetValue()
+				}
+			}
+			counts[method+"/"+success] = m.GetHistogram().GetSampleCount()
+		}
+
+		assert.Equal(t, map[string]uint64{"Get/true": 1, "Get/false": 2}, counts)
+	})
+
+	t.Run("unexpected labels", func(t *testing.T) {
+		for _, labels := range [][]string{{"method", "result"}, {"method", "success", "instance"}} {
+			func() {
+				defer func() {
+					assert.Contains(t, recover(), "NewWatcherTestInterfaceWithPrometheusHistogram: histogram must have the method and success labels: ")
+				}()
+
+				NewWatcherTestInterfaceWithPrometheusHistogram(&watcherTestImpl{}, newTestHistogram(labels...))
+			}()
+		}
+	})
+
+	t.Run("no instrumented methods", func(t *testing.T) {
+		func() {
+			defer func() {
+				assert.Contains(t, recover(), "NewWatcherTestInterfaceWithUntimedHistogram: histogram must have the method and success labels: ")
+			}()
+
+			NewWatcherTestInterfaceWithUntimedHistogram(&watcherTestImpl{}, newTestHistogram("method"))
+		}()
+
+		histogram := newTestHistogram("method", "success")
+		NewWatcherTestInterfaceWithUntimedHistogram(&watcherTestImpl{}, histogram)
+
+		registry := prometheus.NewRegistry()
+		require.NoError(t, registry.Register(histogram))
+
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		assert.Empty(t, families, "the series used to check the labels must be deleted")
+	})
+
+	t.Run("nil histogram", func(t *testing.T) {
+		assert.PanicsWithValue(t, "NewWatcherTestInterfaceWithPrometheusHistogram: histogram is nil", func() {
+			NewWatcherTestInterfaceWithPrometheusHistogram(&watcherTestImpl{}, nil)
+		})
+	})
+}
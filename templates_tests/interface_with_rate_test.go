@@ -0,0 +1,94 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+type rateTestImpl struct {
+	calls int64
+}
+
+func (r *rateTestImpl) Get(ctx context.Context, key string) (string, error) {
+	atomic.AddInt64(&r.calls, 1)
+	return key, nil
+}
+
+func (r *rateTestImpl) Set(key, value string) error {
+	atomic.AddInt64(&r.calls, 1)
+	return nil
+}
+
+func (r *rateTestImpl) Touch(ctx context.Context, key string) {
+	atomic.AddInt64(&r.calls, 1)
+}
+
+func (r *rateTestImpl) Len() int {
+	return int(atomic.AddInt64(&r.calls, 1))
+}
+
+var errTestLimited = errors.New("rate limited")
+
+func TestRateTestInterfaceWithRate_Get(t *testing.T) {
+	impl := &rateTestImpl{}
+	wrapped := NewRateTestInterfaceWithRate(impl, rate.NewLimiter(rate.Every(time.Hour), 1), errTestLimited)
+
+	value, err := wrapped.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "key", value)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = wrapped.Get(ctx, "key")
+	assert.Error(t, err, "the wait would exceed the context deadline")
+	assert.EqualValues(t, 1, atomic.LoadInt64(&impl.calls))
+}
+
+func TestRateTestInterfaceWithRate_Set(t *testing.T) {
+	impl := &rateTestImpl{}
+	wrapped := NewRateTestInterfaceWithRate(impl, rate.NewLimiter(rate.Every(time.Hour), 1), errTestLimited)
+
+	require.NoError(t, wrapped.Set("key", "value"))
+	assert.Equal(t, errTestLimited, wrapped.Set("key", "value"))
+	assert.EqualValues(t, 1, atomic.LoadInt64(&impl.calls))
+}
+
+func TestRateTestInterfaceWithRate_Touch(t *testing.T) {
+	impl := &rateTestImpl{}
+	wrapped := NewRateTestInterfaceWithRate(impl, rate.NewLimiter(rate.Every(time.Hour), 1), errTestLimited)
+
+	wrapped.Touch(context.Background(), "key")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	wrapped.Touch(ctx, "key")
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "the wait must end when the context is done")
+	assert.EqualValues(t, 2, atomic.LoadInt64(&impl.calls))
+}
+
+func TestRateTestInterfaceWithRate_Len(t *testing.T) {
+	const interval = 50 * time.Millisecond
+
+	wrapped := NewRateTestInterfaceWithRate(&rateTestImpl{}, rate.NewLimiter(rate.Every(interval), 1), errTestLimited)
+
+	start := time.Now()
+	assert.Equal(t, 1, wrapped.Len())
+	assert.Equal(t, 2, wrapped.Len())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(interval*4/5), "the second call must wait for the limiter")
+}
+
+func TestNewRateTestInterfaceWithRate(t *testing.T) {
+	assert.PanicsWithValue(t, "NewRateTestInterfaceWithRate: errLimited is nil", func() {
+		NewRateTestInterfaceWithRate(&rateTestImpl{}, rate.NewLimiter(rate.Inf, 1), nil)
+	})
+}
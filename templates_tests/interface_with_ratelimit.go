@@ -56,6 +56,9 @@ func (_d *TestInterfaceWithRateLimit) ContextNoError(ctx context.Context, a1 str
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d *TestInterfaceWithRateLimit) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	select {
@@ -75,6 +78,10 @@ func (_d *TestInterfaceWithRateLimit) NoError(s1 string) (s2 string) {
 	return _d._base.NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d *TestInterfaceWithRateLimit) NoParamsOrResults() {
 	<-_d._ticks
@@ -0,0 +1,47 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recentCallsSummary(calls []TestInterfaceWithRecentCallsCallRecord) []string {
+	var summary []string
+	for _, call := range calls {
+		summary = append(summary, call.Method+"("+call.Args+")")
+	}
+	return summary
+}
+
+func TestTestInterfaceWithRecentCalls_RecentCalls(t *testing.T) {
+	wrapped := NewTestInterfaceWithRecentCalls(&testImpl{}, 3)
+	assert.Empty(t, wrapped.RecentCalls())
+
+	_, _, err := wrapped.F(context.Background(), "a1", "a2", "a3")
+	require.NoError(t, err)
+	wrapped.NoParamsOrResults()
+
+	calls := wrapped.RecentCalls()
+	assert.Equal(t, []string{"F(a1=a1, a2=[a2 a3])", "NoParamsOrResults()"}, recentCallsSummary(calls))
+	assert.False(t, calls[0].Time.IsZero())
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	wrapped.ContextNoError(context.Background(), "a1", "a2")
+
+	assert.Equal(t, []string{"NoParamsOrResults()", "NoError(s1=value)", "ContextNoError(a1=a1, a2=a2)"}, recentCallsSummary(wrapped.RecentCalls()))
+
+	wrapped.NoParamsOrResults()
+	wrapped.NoParamsOrResults()
+
+	assert.Equal(t, []string{"ContextNoError(a1=a1, a2=a2)", "NoParamsOrResults()", "NoParamsOrResults()"}, recentCallsSummary(wrapped.RecentCalls()))
+}
+
+func TestTestInterfaceWithRecentCalls_zeroSize(t *testing.T) {
+	wrapped := NewTestInterfaceWithRecentCalls(&testImpl{}, 0)
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	assert.Empty(t, wrapped.RecentCalls())
+}
@@ -0,0 +1,52 @@
+package templatestests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type panicImpl struct {
+	err error
+}
+
+func (p panicImpl) First(s string) error {
+	if s == "panic" {
+		panic("first")
+	}
+	return p.err
+}
+
+func (p panicImpl) Second(s string) error {
+	if s == "panic" {
+		panic("second")
+	}
+	return p.err
+}
+
+func (p panicImpl) NoError(s string) string {
+	return s
+}
+
+func TestErrorsTestInterfaceWithRecover_First(t *testing.T) {
+	t.Run("no panic", func(t *testing.T) {
+		errUnexpected := errors.New("unexpected error")
+		wrapped := NewErrorsTestInterfaceWithRecover(panicImpl{err: errUnexpected})
+
+		assert.Equal(t, errUnexpected, wrapped.First("value"))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		wrapped := NewErrorsTestInterfaceWithRecover(panicImpl{})
+
+		assert.EqualError(t, wrapped.First("panic"), "ErrorsTestInterface.First: panic: first")
+	})
+}
+
+func TestErrorsTestInterfaceWithRecover_Second(t *testing.T) {
+	wrapped := NewErrorsTestInterfaceWithRecover(panicImpl{})
+
+	assert.NoError(t, wrapped.Second("value"))
+	assert.PanicsWithValue(t, "second", func() { _ = wrapped.Second("panic") })
+}
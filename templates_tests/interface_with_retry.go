@@ -14,32 +14,51 @@ import (
 // TestInterfaceWithRetry implements TestInterface interface instrumented with retries
 type TestInterfaceWithRetry struct {
 	TestInterface
-	_retryCount    int
-	_retryInterval time.Duration
+	_retries int
+	_backoff func(attempt int) time.Duration
 }
 
-// NewTestInterfaceWithRetry returns TestInterfaceWithRetry
+// NewTestInterfaceWithRetry returns TestInterfaceWithRetry that waits retryInterval before every retry
 func NewTestInterfaceWithRetry(base TestInterface, retryCount int, retryInterval time.Duration) TestInterfaceWithRetry {
+	return NewTestInterfaceWithRetryWithBackoff(base, retryCount, func(int) time.Duration {
+		return retryInterval
+	})
+}
+
+// NewTestInterfaceWithRetryWithBackoff returns TestInterfaceWithRetry that retries failed calls up to the given number of times,
+// backoff returns the delay before the given retry attempt starting from 1
+func NewTestInterfaceWithRetryWithBackoff(base TestInterface, retries int, backoff func(attempt int) time.Duration) TestInterfaceWithRetry {
 	return TestInterfaceWithRetry{
-		TestInterface:  base,
-		_retryCount:    retryCount,
-		_retryInterval: retryInterval,
+		TestInterface: base,
+		_retries:      retries,
+		_backoff:      backoff,
+	}
+}
+
+// TestInterfaceWithRetryExponentialBackoff returns the backoff doubling the interval with every retry attempt
+func TestInterfaceWithRetryExponentialBackoff(interval time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		return interval << (attempt - 1)
 	}
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithRetry) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	result1, result2, err = _d.TestInterface.F(ctx, a1, a2...)
-	if err == nil || _d._retryCount < 1 {
+	if err == nil {
 		return
 	}
-	_ticker := time.NewTicker(_d._retryInterval)
-	defer _ticker.Stop()
-	for _i := 0; _i < _d._retryCount && err != nil; _i++ {
+	for _attempt := 1; _attempt <= _d._retries && err != nil; _attempt++ {
+		_timer := time.NewTimer(_d._backoff(_attempt))
 		select {
 		case <-ctx.Done():
+			_timer.Stop()
+			err = ctx.Err()
 			return
-		case <-_ticker.C:
+		case <-_timer.C:
 		}
 		result1, result2, err = _d.TestInterface.F(ctx, a1, a2...)
 	}
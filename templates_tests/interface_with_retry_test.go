@@ -50,3 +50,45 @@ func TestTestInterfaceWithRetry_F(t *testing.T) {
 		assert.EqualValues(t, 1, impl.callCounter)
 	})
 }
+
+func TestNewTestInterfaceWithRetryWithBackoff(t *testing.T) {
+	t.Run("retries with backoff", func(t *testing.T) {
+		errUnexpected := errors.New("unexpected error")
+		impl := &testImpl{err: errUnexpected}
+
+		var attempts []int
+		wrapped := NewTestInterfaceWithRetryWithBackoff(impl, 2, func(attempt int) time.Duration {
+			attempts = append(attempts, attempt)
+			return time.Millisecond
+		})
+
+		_, _, err := wrapped.F(context.Background(), "a1")
+		assert.Equal(t, errUnexpected, err)
+		assert.Equal(t, []int{1, 2}, attempts)
+		assert.EqualValues(t, 3, impl.callCounter)
+	})
+
+	t.Run("context canceled during backoff", func(t *testing.T) {
+		impl := &testImpl{err: errors.New("unexpected error")}
+
+		ctx, cancelFunc := context.WithCancel(context.Background())
+		defer cancelFunc()
+
+		wrapped := NewTestInterfaceWithRetryWithBackoff(impl, 2, func(int) time.Duration {
+			cancelFunc()
+			return time.Hour
+		})
+
+		_, _, err := wrapped.F(ctx, "a1")
+		assert.Equal(t, context.Canceled, err)
+		assert.EqualValues(t, 1, impl.callCounter)
+	})
+}
+
+func TestTestInterfaceWithRetryExponentialBackoff(t *testing.T) {
+	backoff := TestInterfaceWithRetryExponentialBackoff(10 * time.Millisecond)
+
+	assert.Equal(t, 10*time.Millisecond, backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff(3))
+}
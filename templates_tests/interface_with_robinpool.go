@@ -53,6 +53,9 @@ func (_d *TestInterfaceRoundRobinPool) ContextNoError(ctx context.Context, a1 st
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d *TestInterfaceRoundRobinPool) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_counter := atomic.AddUint32(&_d.counter, 1)
@@ -65,6 +68,10 @@ func (_d *TestInterfaceRoundRobinPool) NoError(s1 string) (s2 string) {
 	return _d.pool[_counter%_d.poolSize].NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d *TestInterfaceRoundRobinPool) NoParamsOrResults() {
 	_counter := atomic.AddUint32(&_d.counter, 1)
@@ -0,0 +1,53 @@
+package templatestests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sequenceTestImpl struct {
+	testImpl
+	seq []uint64
+}
+
+func (s *sequenceTestImpl) F(ctx context.Context, a1 string, a2 ...string) (r1, r2 string, err error) {
+	if seq, ok := TestInterfaceWithSequenceFromContext(ctx); ok {
+		s.seq = append(s.seq, seq)
+	}
+
+	return s.testImpl.F(ctx, a1, a2...)
+}
+
+func TestNewTestInterfaceWithSequence(t *testing.T) {
+	impl := &sequenceTestImpl{}
+
+	var calls []string
+	var seq []uint64
+	wrapped := NewTestInterfaceWithSequence(impl, func(method string, s uint64) {
+		calls = append(calls, method)
+		seq = append(seq, s)
+	})
+
+	_, _, _ = wrapped.F(context.Background(), "a1")
+	wrapped.NoParamsOrResults()
+	_ = wrapped.NoError("value")
+	wrapped.ContextNoError(context.Background(), "a1", "a2")
+	_, _, _ = wrapped.F(context.Background(), "a1")
+
+	assert.Equal(t, []string{"F", "NoParamsOrResults", "NoError", "ContextNoError", "F"}, calls)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, seq)
+	assert.Equal(t, []uint64{1, 5}, impl.seq)
+	assert.EqualValues(t, 5, wrapped.Sequence())
+
+	_, ok := TestInterfaceWithSequenceFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestNewTestInterfaceWithSequence_noCallback(t *testing.T) {
+	wrapped := NewTestInterfaceWithSequence(&testImpl{}, nil)
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	assert.EqualValues(t, 1, wrapped.Sequence())
+}
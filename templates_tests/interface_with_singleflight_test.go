@@ -0,0 +1,81 @@
+package templatestests
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keyValueImpl struct {
+	calls   int64
+	release chan struct{}
+}
+
+func (k *keyValueImpl) Get(ctx context.Context, key string) (string, error) {
+	atomic.AddInt64(&k.calls, 1)
+	<-k.release
+	return "value of " + key, nil
+}
+
+func (k *keyValueImpl) GetMany(ctx context.Context, keys []string) ([]string, error) {
+	atomic.AddInt64(&k.calls, 1)
+	return keys, nil
+}
+
+func (k *keyValueImpl) Set(ctx context.Context, key, value string) error {
+	atomic.AddInt64(&k.calls, 1)
+	return nil
+}
+
+func TestKeyValueTestInterfaceWithSingleflight_Get(t *testing.T) {
+	impl := &keyValueImpl{release: make(chan struct{})}
+	wrapped := NewKeyValueTestInterfaceWithSingleflight(impl)
+
+	const callers = 5
+
+	var wg sync.WaitGroup
+	values := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var err error
+			values[i], err = wrapped.Get(context.Background(), "key")
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	//wait for the first call to reach the base implementation and the rest of the callers to join it
+	require.Eventually(t, func() bool { return atomic.LoadInt64(&impl.calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(impl.release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt64(&impl.calls))
+	for _, v := range values {
+		assert.Equal(t, "value of key", v)
+	}
+
+	v, err := wrapped.Get(context.Background(), "another key")
+	require.NoError(t, err)
+	assert.Equal(t, "value of another key", v)
+	assert.EqualValues(t, 2, atomic.LoadInt64(&impl.calls))
+}
+
+func TestKeyValueTestInterfaceWithSingleflight_passThrough(t *testing.T) {
+	impl := &keyValueImpl{}
+	wrapped := NewKeyValueTestInterfaceWithSingleflight(impl)
+
+	values, err := wrapped.GetMany(context.Background(), []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	assert.NoError(t, wrapped.Set(context.Background(), "key", "value"))
+	assert.EqualValues(t, 2, impl.calls)
+}
@@ -0,0 +1,66 @@
+package templatestests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slowContextNoErrorImpl struct {
+	testImpl
+	delay time.Duration
+}
+
+func (s *slowContextNoErrorImpl) ContextNoError(ctx context.Context, a1 string, a2 string) {
+	time.Sleep(s.delay)
+}
+
+func TestTestInterfaceWithSLA_F(t *testing.T) {
+	t.Run("within max latency", func(t *testing.T) {
+		wrapped := NewTestInterfaceWithSLA(&testImpl{r1: "1", r2: "2"})
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+	})
+
+	t.Run("max latency exceeded", func(t *testing.T) {
+		wrapped := NewTestInterfaceWithSLA(&testImpl{r1: "1", r2: "2", delay: 20 * time.Millisecond})
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrTestInterfaceWithSLAMaxLatency))
+		assert.Contains(t, err.Error(), "TestInterface.F took ")
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+	})
+
+	t.Run("max latency exceeded with error", func(t *testing.T) {
+		errUnexpected := errors.New("unexpected error")
+		wrapped := NewTestInterfaceWithSLA(&testImpl{err: errUnexpected, delay: 20 * time.Millisecond})
+
+		_, _, err := wrapped.F(context.Background(), "a1")
+		assert.Equal(t, errUnexpected, err)
+	})
+}
+
+func TestTestInterfaceWithSLA_ContextNoError(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	NewTestInterfaceWithSLA(&slowContextNoErrorImpl{}).ContextNoError(context.Background(), "a1", "a2")
+	assert.Empty(t, buf.String())
+
+	NewTestInterfaceWithSLA(&slowContextNoErrorImpl{delay: 20 * time.Millisecond}).ContextNoError(context.Background(), "a1", "a2")
+	assert.Contains(t, buf.String(), "TestInterface.ContextNoError took ")
+	assert.Contains(t, buf.String(), "max latency 10ms exceeded")
+}
@@ -0,0 +1,59 @@
+package templatestests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSlogLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestTestInterfaceWithSlog_F(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		buf := bytes.NewBuffer([]byte{})
+		wrapped := NewTestInterfaceWithSlog(&testImpl{r1: "1", r2: "2"}, newTestSlogLogger(buf))
+
+		r1, r2, err := wrapped.F(context.Background(), "secret", "p2", "p3")
+		require.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+
+		assert.Equal(t, "level=INFO msg=\"TestInterface.F: calling\" a2=\"[p2 p3]\"\n"+
+			"level=INFO msg=\"TestInterface.F: finished\"\n", buf.String())
+		assert.NotContains(t, buf.String(), "secret", "redacted param is logged")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		buf := bytes.NewBuffer([]byte{})
+		wrapped := NewTestInterfaceWithSlog(&testImpl{err: errors.New("failure")}, newTestSlogLogger(buf))
+
+		_, _, err := wrapped.F(context.Background(), "secret", "p2")
+		require.Error(t, err)
+
+		assert.Equal(t, "level=INFO msg=\"TestInterface.F: calling\" a2=[p2]\n"+
+			"level=ERROR msg=\"TestInterface.F: failed\" error=failure\n", buf.String())
+	})
+}
+
+func TestTestInterfaceWithSlog_NoError(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	wrapped := NewTestInterfaceWithSlog(&testImpl{}, newTestSlogLogger(buf))
+
+	assert.Equal(t, "value", wrapped.NoError("value"))
+	assert.Equal(t, "level=INFO msg=\"TestInterface.NoError: calling\" s1=value\n"+
+		"level=INFO msg=\"TestInterface.NoError: finished\"\n", buf.String())
+}
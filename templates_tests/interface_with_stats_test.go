@@ -0,0 +1,38 @@
+package templatestests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestInterfaceWithStats_Stats(t *testing.T) {
+	wrapped := NewTestInterfaceWithStats(&testImpl{})
+
+	assert.Equal(t, map[string]int64{
+		"Channels":          0,
+		"ContextNoError":    0,
+		"F":                 0,
+		"NoError":           0,
+		"NoParamsOrResults": 0,
+	}, wrapped.Stats())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wrapped.NoError("")
+		}()
+	}
+	wg.Wait()
+
+	wrapped.F(context.Background(), "a1")
+
+	stats := wrapped.Stats()
+	assert.EqualValues(t, 10, stats["NoError"])
+	assert.EqualValues(t, 1, stats["F"])
+	assert.EqualValues(t, 0, stats["Channels"])
+}
@@ -48,6 +48,9 @@ func (_d TestInterfacePool) ContextNoError(ctx context.Context, a1 string, a2 st
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfacePool) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	_impl := <-_d.pool
@@ -66,6 +69,10 @@ func (_d TestInterfacePool) NoError(s1 string) (s2 string) {
 	return _impl.NoError(s1)
 }
 
+// NoParamsOrResults has neither params nor results.
+//
+// Deprecated: it's used to test forwarding of the deprecation notices to the decorators.
+//
 // NoParamsOrResults implements TestInterface
 func (_d TestInterfacePool) NoParamsOrResults() {
 	_impl := <-_d.pool
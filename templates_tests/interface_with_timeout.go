@@ -4,7 +4,7 @@
 
 package templatestests
 
-//go:generate gowrap gen -p github.com/hexdigest/gowrap/templates_tests -i TestInterface -t ../templates/timeout -o interface_with_timeout.go -l ""
+//go:generate gowrap gen -p github.com/hexdigest/gowrap/templates_tests -i TestInterface -t ../templates/timeout -o interface_with_timeout.go -v Timeouts=F=100*time.Millisecond -v DefaultTimeout=time.Second -l ""
 
 import (
 	"context"
@@ -23,6 +23,15 @@ type TestInterfaceWithTimeoutConfig struct {
 	FTimeout time.Duration
 }
 
+// DefaultTestInterfaceWithTimeoutConfig returns TestInterfaceWithTimeoutConfig with the timeouts
+// set with the Timeouts and DefaultTimeout template vars
+func DefaultTestInterfaceWithTimeoutConfig() TestInterfaceWithTimeoutConfig {
+	return TestInterfaceWithTimeoutConfig{
+		ContextNoErrorTimeout: time.Second,
+		FTimeout:              100 * time.Millisecond,
+	}
+}
+
 // NewTestInterfaceWithTimeout returns TestInterfaceWithTimeout
 func NewTestInterfaceWithTimeout(base TestInterface, config TestInterfaceWithTimeoutConfig) TestInterfaceWithTimeout {
 	return TestInterfaceWithTimeout{
@@ -42,6 +51,9 @@ func (_d TestInterfaceWithTimeout) ContextNoError(ctx context.Context, a1 string
 	return
 }
 
+// F accepts a context and variadic params,
+// it returns several results and an error
+//
 // F implements TestInterface
 func (_d TestInterfaceWithTimeout) F(ctx context.Context, a1 string, a2 ...string) (result1 string, result2 string, err error) {
 	var cancelFunc func()
@@ -54,3 +54,10 @@ func TestTestInterfaceWithTimeout_F(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestDefaultTestInterfaceWithTimeoutConfig(t *testing.T) {
+	assert.Equal(t, TestInterfaceWithTimeoutConfig{
+		ContextNoErrorTimeout: time.Second,
+		FTimeout:              100 * time.Millisecond,
+	}, DefaultTestInterfaceWithTimeoutConfig())
+}
@@ -0,0 +1,56 @@
+package templatestests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInterfaceWithValidator_F(t *testing.T) {
+	defer func(v func(method string, params ...interface{}) error) { testValidator = v }(testValidator)
+
+	impl := &testImpl{r1: "1", r2: "2"}
+	wrapped := NewTestInterfaceWithValidator(impl)
+
+	var validated []interface{}
+	var validationErr error
+	testValidator = func(method string, params ...interface{}) error {
+		assert.EqualValues(t, 0, atomic.LoadUint64(&impl.callCounter), "validation must precede the call")
+		validated = append([]interface{}{method}, params...)
+		return validationErr
+	}
+
+	t.Run("invalid params", func(t *testing.T) {
+		validationErr = errors.New("invalid params")
+
+		_, _, err := wrapped.F(context.Background(), "a1", "a2", "a3")
+		assert.Equal(t, validationErr, err)
+		assert.Equal(t, []interface{}{"F", context.Background(), "a1", []string{"a2", "a3"}}, validated)
+		assert.EqualValues(t, 0, atomic.LoadUint64(&impl.callCounter))
+	})
+
+	t.Run("valid params", func(t *testing.T) {
+		validationErr = nil
+
+		r1, r2, err := wrapped.F(context.Background(), "a1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", r1)
+		assert.Equal(t, "2", r2)
+		assert.EqualValues(t, 1, atomic.LoadUint64(&impl.callCounter))
+	})
+}
+
+func TestTestInterfaceWithValidator_NoError(t *testing.T) {
+	defer func(v func(method string, params ...interface{}) error) { testValidator = v }(testValidator)
+
+	testValidator = func(method string, params ...interface{}) error {
+		t.Fatal("methods not returning an error must not be validated")
+		return nil
+	}
+
+	assert.Equal(t, "value", NewTestInterfaceWithValidator(&testImpl{}).NoError("value"))
+}